@@ -22,10 +22,11 @@ func main() {
 
 	log.Info("starting application ", slog.Any("env", cfg))
 
-	application := app.New(log, cfg.GRPC.Port, cfg.GRPC.GatewayPort, cfg.Storage, cfg.TokenTTL)
+	application := app.New(log, cfg)
 
 	go application.GRPCServer.MustRunRPC()
 	go application.GRPCServer.MustRunGateway()
+	application.Jobs.Run()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -35,6 +36,7 @@ func main() {
 	log.Info("Stopping application", slog.String("signal", sign.String()))
 
 	application.GRPCServer.Stop()
+	application.Jobs.Stop()
 
 	log.Info("application stopped")
 }
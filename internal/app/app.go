@@ -1,40 +1,279 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	grpcapp "sso/internal/app/grpc"
+	jobsapp "sso/internal/app/jobs"
 	"sso/internal/config"
+	"sso/internal/domain/models"
+	"sso/internal/lib/envelope"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/ratelimit"
+	"sso/internal/notify"
 	"sso/internal/services/auth"
+	"sso/internal/services/keys"
 	"sso/internal/storage/postgres"
 	"time"
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
+	Jobs       *jobsapp.App
 }
 
 func New(
 	log *slog.Logger,
-	grpcPort int,
-	gatewayPort int,
-	storageCfg config.StorageConfig,
-	tokenTTL time.Duration,
+	cfg *config.Config,
 ) *App {
-	
-	storage, err := postgres.New(storageCfg)
+	storage, err := postgres.New(cfg.Storage)
 	if err != nil {
 		panic(err)
 	}
 
-	authService := auth.New(log, storage, storage, storage, storage, tokenTTL)
+	sealer, err := envelope.NewFromBase64(cfg.Apps.SecretMasterKey)
+	if err != nil {
+		panic(err)
+	}
 
-	grpcApp, err := grpcapp.New(log, authService, grpcPort, gatewayPort)
+	keysService, err := keys.New(
+		context.Background(),
+		log,
+		storage,
+		storage,
+		sealer,
+		map[string]string{
+			jwt.AlgRS256: cfg.Signing.RSAKeyPath,
+			jwt.AlgES256: cfg.Signing.ECDSAKeyPath,
+			jwt.AlgEdDSA: cfg.Signing.Ed25519KeyPath,
+		},
+		cfg.TokenTTL,
+		cfg.Signing.RotationPeriod,
+	)
+	if err != nil {
+		panic(err)
+	}
 
+	mailer, err := newMailer(cfg.Notify)
 	if err != nil {
 		panic(err)
 	}
 
+	authService := auth.New(log, storage, keysService, sealer, mailer, auth.Config{
+		Issuer:               cfg.Issuer,
+		TOTPIssuer:           cfg.MFA.TOTPIssuer,
+		EmailLoginURL:        cfg.Users.EmailLoginURL,
+		TokenTTL:             cfg.TokenTTL,
+		RefreshTokenTTL:      cfg.RefreshTokenTTL,
+		AuthCodeTTL:          cfg.OAuth.AuthorizationCodeTTL,
+		DeviceCodeTTL:        cfg.OAuth.DeviceCodeTTL,
+		DevicePollInterval:   cfg.OAuth.DevicePollInterval,
+		PasswordResetTTL:     cfg.Users.PasswordResetTTL,
+		EmailVerificationTTL: cfg.Users.EmailVerificationTTL,
+		MFAChallengeTTL:      cfg.MFA.ChallengeTTL,
+		WebAuthnSessionTTL:   cfg.WebAuthn.SessionTTL,
+		EmailLoginTTL:        cfg.Users.EmailLoginTTL,
+		AppSecretGracePeriod: cfg.Apps.SecretGracePeriod,
+		LockoutPolicy: auth.LockoutPolicy{
+			FailureWindow:    cfg.Lockout.FailureWindow,
+			BackoffAfter:     cfg.Lockout.BackoffAfter,
+			BackoffBase:      cfg.Lockout.BackoffBase,
+			BackoffMax:       cfg.Lockout.BackoffMax,
+			LockoutThreshold: cfg.Lockout.Threshold,
+			LockoutDuration:  cfg.Lockout.Duration,
+			IPBackoffAfter:   cfg.Lockout.IPBackoffAfter,
+		},
+		DefaultPasswordPolicy: models.PasswordPolicy{
+			MinLength:      cfg.PasswordPolicy.MinLength,
+			MaxLength:      cfg.PasswordPolicy.MaxLength,
+			MinCharClasses: cfg.PasswordPolicy.MinCharClasses,
+			DisallowEmail:  cfg.PasswordPolicy.DisallowEmail,
+			MinStrength:    cfg.PasswordPolicy.MinStrength,
+		},
+	})
+
+	if err := authService.MigrateAppSecrets(context.Background()); err != nil {
+		panic(err)
+	}
+
+	if cfg.Admin.Email != "" {
+		err := authService.EnsureAdmin(context.Background(), cfg.Admin.Email, cfg.Admin.Password, cfg.Admin.AppID)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	rateLimitStore, rateLimits, err := newRateLimits(cfg.RateLimit, storage)
+	if err != nil {
+		panic(err)
+	}
+
+	grpcApp, err := grpcapp.New(
+		log,
+		authService,
+		keysService,
+		authService,
+		cfg.Issuer,
+		rateLimitStore,
+		rateLimits,
+		cfg.GRPC.Port,
+		cfg.GRPC.GatewayPort,
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	jobs := []jobsapp.Job{
+		jobsapp.Job{
+			Name:     "revoked tokens cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupRevokedTokens,
+		},
+		jobsapp.Job{
+			Name:     "authorization codes cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupAuthorizationCodes,
+		},
+		jobsapp.Job{
+			Name:     "device codes cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupDeviceCodes,
+		},
+		jobsapp.Job{
+			Name:     "password reset tokens cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupPasswordResetTokens,
+		},
+		jobsapp.Job{
+			Name:     "email verification tokens cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupEmailVerificationTokens,
+		},
+		jobsapp.Job{
+			Name:     "mfa challenges cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupMFAChallenges,
+		},
+		jobsapp.Job{
+			Name:     "webauthn sessions cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupWebAuthnSessions,
+		},
+		jobsapp.Job{
+			Name:     "email logins cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupEmailLogins,
+		},
+		jobsapp.Job{
+			Name:     "login throttles cleanup",
+			Interval: cfg.GCInterval,
+			Run:      authService.CleanupLoginThrottles,
+		},
+		jobsapp.Job{
+			Name:     "signing keys rotation",
+			Interval: cfg.Signing.RotationCheckInterval,
+			Run:      keysService.RotateDue,
+		},
+	}
+
+	if rateLimitStore != nil && cfg.RateLimit.Backend == "postgres" {
+		idle := rateLimitFillTime(rateLimits)
+
+		jobs = append(jobs, jobsapp.Job{
+			Name:     "rate limit buckets cleanup",
+			Interval: cfg.GCInterval,
+			Run: func(ctx context.Context) error {
+				_, err := storage.DeleteIdleRateLimitBuckets(ctx, time.Now().Add(-idle))
+
+				return err
+			},
+		})
+	}
+
 	return &App{
 		GRPCServer: grpcApp,
+		Jobs:       jobsapp.New(log, jobs...),
 	}
 }
+
+func newMailer(cfg config.NotifyConfig) (*notify.Mailer, error) {
+	templates, err := notify.LoadTemplates(cfg.TemplatesDir, cfg.DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifier notify.Notifier
+
+	switch cfg.Backend {
+	case "smtp":
+		if cfg.SMTP.Host == "" {
+			return nil, fmt.Errorf("notify.smtp.host is required for the smtp backend")
+		}
+
+		notifier, err = notify.NewSMTP(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.Security)
+		if err != nil {
+			return nil, err
+		}
+	case "file":
+		notifier, err = notify.NewFile(cfg.File.Path)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown notify backend %q", cfg.Backend)
+	}
+
+	return notify.NewMailer(notifier, templates, cfg.From), nil
+}
+
+func newRateLimits(cfg config.RateLimitConfig, storage *postgres.Storage) (ratelimit.Store, []grpcapp.RateLimitRule, error) {
+	if !cfg.Enabled {
+		return nil, nil, nil
+	}
+
+	var store ratelimit.Store
+
+	switch cfg.Backend {
+	case "postgres":
+		store = ratelimit.StoreFunc(storage.TakeRateLimitToken)
+	case "memory", "":
+		store = ratelimit.NewMemoryStore()
+	default:
+		return nil, nil, fmt.Errorf("unknown rate limit backend %q", cfg.Backend)
+	}
+
+	configRules := cfg.Rules
+	if len(configRules) == 0 {
+		configRules = config.DefaultRateLimitRules
+	}
+
+	rules := make([]grpcapp.RateLimitRule, 0, len(configRules))
+
+	for _, rule := range configRules {
+		if rule.Requests <= 0 || rule.Period <= 0 {
+			return nil, nil, fmt.Errorf("rate limit of %s needs positive requests and period", rule.Method)
+		}
+
+		rules = append(rules, grpcapp.RateLimitRule{
+			Method: rule.Method,
+			Key:    rule.Key,
+			Limit:  ratelimit.Every(rule.Requests, rule.Period, rule.Burst),
+		})
+	}
+
+	return store, rules, nil
+}
+
+// rateLimitFillTime is how long the bucket of the slowest rule takes to fill
+// up, after which idle buckets can be dropped.
+func rateLimitFillTime(rules []grpcapp.RateLimitRule) time.Duration {
+	var fillTime time.Duration
+
+	for _, rule := range rules {
+		fillTime = max(fillTime, rule.Limit.FillTime())
+	}
+
+	return fillTime
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	authgrpc "sso/internal/grpc/auth"
+	"sso/internal/lib/ratelimit"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
@@ -37,17 +38,46 @@ const (
 	serverKeyFile  = "cert/server-key.pem"
 )
 
+// New creates the gRPC server and the gateway. A nil rateLimitStore turns
+// rate limiting off.
 func New(
 	log *slog.Logger,
 	authService authgrpc.Auth,
+	keysService authgrpc.Keys,
+	verifier TokenVerifier,
+	issuer string,
+	rateLimitStore ratelimit.Store,
+	rateLimits []RateLimitRule,
 	portRPC int,
 	portGateway int,
 ) (*App, error) {
 
 	creds, err := loadTLSCredentials()
 
+	interceptors := []grpc.UnaryServerInterceptor{
+		clientIPInterceptor(),
+		authInterceptor(log, verifier, authgrpc.Rules),
+	}
+
+	var limiter authgrpc.RateLimiter
+
+	if rateLimitStore != nil {
+		rules, err := rateLimitRules(rateLimits)
+		if err != nil {
+			return nil, err
+		}
+
+		rateLimiter := &rateLimiter{log: log, store: rateLimitStore, rules: rules}
+		limiter = rateLimiter
+
+		interceptors = append(interceptors, rateLimitInterceptor(rateLimiter))
+	}
+
+	interceptors = append(interceptors, localeInterceptor())
+
 	gRPCServer := grpc.NewServer(
 		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(interceptors...),
 	)
 
 	reflection.Register(gRPCServer)
@@ -57,7 +87,7 @@ func New(
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	authgrpc.Register(ctx, gRPCGateway, gRPCServer, authService)
+	authgrpc.Register(ctx, gRPCGateway, gRPCServer, newInProcessConn(interceptors...), limiter, authService, keysService, issuer)
 
 	return &App{
 		log:         log,
@@ -0,0 +1,96 @@
+package grpcapp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	authgrpc "sso/internal/grpc/auth"
+	"sso/internal/lib/jwt"
+	"sso/internal/services/auth"
+	"sso/internal/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenVerifier authenticates the callers of protected RPCs.
+type TokenVerifier interface {
+	VerifyUserToken(ctx context.Context, accessToken string) (jwt.Claims, error)
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+// authInterceptor enforces the access rules of the RPCs with the bearer token
+// of the call. Admin rights are looked up on every call, so taking them away
+// takes effect immediately rather than when the token expires.
+func authInterceptor(
+	log *slog.Logger,
+	verifier TokenVerifier,
+	rules map[string]authgrpc.Access,
+) grpc.UnaryServerInterceptor {
+	const op = "grpcapp.authInterceptor"
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		access, ok := rules[info.FullMethod]
+		if !ok {
+			log.Warn("no access rule for method", slog.String("op", op), slog.String("method", info.FullMethod))
+
+			return nil, status.Error(codes.PermissionDenied, "access denied")
+		}
+
+		if access == authgrpc.AccessPublic {
+			return handler(ctx, req)
+		}
+
+		token, err := authgrpc.BearerToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		claims, err := verifier.VerifyUserToken(ctx, token)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidToken) {
+				return nil, status.Error(codes.Unauthenticated, "invalid token")
+			}
+
+			log.Error("failed to verify token", slog.String("op", op), slog.String("error:", err.Error()))
+
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		if claims.UID == "" {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		ctx = withSubject(ctx, claims.UID)
+
+		if access == authgrpc.AccessAdmin {
+			isAdmin, err := verifier.IsAdmin(ctx, claims.UID)
+			if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
+				log.Error("failed to check admin", slog.String("op", op), slog.String("error:", err.Error()))
+
+				return nil, status.Error(codes.Internal, "internal error")
+			}
+
+			if !isAdmin {
+				return nil, status.Error(codes.PermissionDenied, "admin rights are required")
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+type subjectKey struct{}
+
+func withSubject(ctx context.Context, uid string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, uid)
+}
+
+// subject returns the user authenticated by authInterceptor, or "" for
+// public RPCs.
+func subject(ctx context.Context) string {
+	uid, _ := ctx.Value(subjectKey{}).(string)
+
+	return uid
+}
@@ -0,0 +1,29 @@
+package grpcapp
+
+import (
+	"context"
+	"sso/internal/lib/clientip"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientIPInterceptor records the address of the caller: the peer of gRPC
+// calls, or the address the gateway appends to the "x-forwarded-for"
+// metadata of the calls it makes in process, which have no peer.
+func clientIPInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ctx = clientip.WithIP(ctx, clientip.FromAddr(p.Addr.String()))
+		} else if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-forwarded-for"); len(values) > 0 {
+				if ip := clientip.FromForwardedFor(values[len(values)-1]); ip != "" {
+					ctx = clientip.WithIP(ctx, ip)
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
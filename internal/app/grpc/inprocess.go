@@ -0,0 +1,97 @@
+package grpcapp
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// inProcessConn is the connection of the gateway. Services registered on it
+// are called in process, but through the same interceptors as calls made over
+// the network, so the gateway can not be used to bypass them.
+type inProcessConn struct {
+	services     map[string]inProcessService
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+type inProcessService struct {
+	desc *grpc.ServiceDesc
+	impl any
+}
+
+func newInProcessConn(interceptors ...grpc.UnaryServerInterceptor) *inProcessConn {
+	return &inProcessConn{
+		services:     make(map[string]inProcessService),
+		interceptors: interceptors,
+	}
+}
+
+func (c *inProcessConn) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	c.services[desc.ServiceName] = inProcessService{desc: desc, impl: impl}
+}
+
+func (c *inProcessConn) Invoke(ctx context.Context, method string, args any, reply any, _ ...grpc.CallOption) error {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "malformed method name %q", method)
+	}
+
+	service, ok := c.services[serviceName]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown service %s", serviceName)
+	}
+
+	for _, desc := range service.desc.Methods {
+		if desc.MethodName != methodName {
+			continue
+		}
+
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+
+		dec := func(in any) error {
+			proto.Merge(in.(proto.Message), args.(proto.Message))
+
+			return nil
+		}
+
+		resp, err := desc.Handler(service.impl, ctx, dec, c.intercept)
+		if err != nil {
+			return err
+		}
+
+		proto.Merge(reply.(proto.Message), resp.(proto.Message))
+
+		return nil
+	}
+
+	return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+}
+
+func (c *inProcessConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streaming is not supported by the gateway")
+}
+
+// intercept runs the interceptors in order, like grpc.ChainUnaryInterceptor.
+func (c *inProcessConn) intercept(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], handler
+
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	return handler(ctx, req)
+}
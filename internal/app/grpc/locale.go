@@ -0,0 +1,33 @@
+package grpcapp
+
+import (
+	"context"
+	"sso/internal/notify"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// localeInterceptor makes messages sent while handling a call use the
+// language preferred by the caller: the "accept-language" metadata of gRPC
+// calls, or the Accept-Language header of gateway calls.
+func localeInterceptor() grpc.UnaryServerInterceptor {
+	keys := []string{"accept-language", runtime.MetadataPrefix + "accept-language"}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 {
+				if locale := notify.ParseAcceptLanguage(values[0]); locale != "" {
+					ctx = notify.WithLocale(ctx, locale)
+
+					break
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
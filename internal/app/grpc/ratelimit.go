@@ -0,0 +1,169 @@
+package grpcapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	authgrpc "sso/internal/grpc/auth"
+	"sso/internal/lib/clientip"
+	"sso/internal/lib/ratelimit"
+	ssov1 "sso/streaming/go/sso"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Keys of rate limit rules, which tell what calls are counted by.
+const (
+	RateLimitByIP      = "ip"
+	RateLimitByApp     = "app"
+	RateLimitBySubject = "subject"
+)
+
+var ErrInvalidRateLimitRule = errors.New("invalid rate limit rule")
+
+// RateLimitRule limits the calls of the RPC Method, e.g. "Login", counted
+// by Key.
+type RateLimitRule struct {
+	Method string
+	Key    string
+	Limit  ratelimit.Limit
+}
+
+// rateLimitRules returns the rules by the full method name of their RPC.
+func rateLimitRules(rules []RateLimitRule) (map[string]RateLimitRule, error) {
+	const op = "grpcapp.rateLimitRules"
+
+	byMethod := make(map[string]RateLimitRule, len(rules))
+
+	for _, rule := range rules {
+		method := "/" + ssov1.Auth_ServiceDesc.ServiceName + "/" + rule.Method
+
+		if _, ok := authgrpc.Rules[method]; !ok {
+			return nil, fmt.Errorf("%s %w: unknown method %q", op, ErrInvalidRateLimitRule, rule.Method)
+		}
+
+		switch rule.Key {
+		case RateLimitByIP, RateLimitByApp, RateLimitBySubject:
+		default:
+			return nil, fmt.Errorf("%s %w: unknown key %q of %s", op, ErrInvalidRateLimitRule, rule.Key, rule.Method)
+		}
+
+		if rule.Limit.Rate <= 0 || rule.Limit.Burst <= 0 {
+			return nil, fmt.Errorf("%s %w: %s allows no calls", op, ErrInvalidRateLimitRule, rule.Method)
+		}
+
+		byMethod[method] = rule
+	}
+
+	return byMethod, nil
+}
+
+// rateLimiter takes tokens from the buckets of the rules. Calls are let
+// through when the store fails, so that an outage of a shared store does not
+// take the service down.
+type rateLimiter struct {
+	log   *slog.Logger
+	store ratelimit.Store
+	rules map[string]RateLimitRule
+}
+
+// allow refuses a call of the RPC fullMethod over the limit of its rule with
+// ResourceExhausted, telling the caller when to try again.
+func (l *rateLimiter) allow(ctx context.Context, fullMethod string, req any) error {
+	const op = "grpcapp.rateLimiter.allow"
+
+	rule, ok := l.rules[fullMethod]
+	if !ok {
+		return nil
+	}
+
+	key := rateLimitKey(ctx, req, rule.Key)
+	if key == "" {
+		return nil
+	}
+
+	ok, retryAfter, err := l.store.TakeToken(ctx, fullMethod+":"+key, rule.Limit, time.Now())
+	if err != nil {
+		l.log.Error("failed to take rate limit token", slog.String("op", op), slog.String("error:", err.Error()))
+
+		return nil
+	}
+
+	if !ok {
+		l.log.Warn("rate limit exceeded", slog.String("op", op), slog.String("method", fullMethod))
+
+		return rateLimitError(retryAfter)
+	}
+
+	return nil
+}
+
+// Allow limits the pages of the gateway that are not RPCs by the rule of the
+// RPC method, e.g. "VerifyDevice". It implements authgrpc.RateLimiter.
+func (l *rateLimiter) Allow(ctx context.Context, method string) error {
+	return l.allow(ctx, "/"+ssov1.Auth_ServiceDesc.ServiceName+"/"+method, nil)
+}
+
+// rateLimitInterceptor refuses calls over the limit of the rule of their
+// RPC. It runs after authInterceptor, which records the subject of the call.
+func rateLimitInterceptor(limiter *rateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := limiter.allow(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitKey returns what the call is counted by, or "" when it can not be
+// told. Calls without an app or a subject are counted by the client IP.
+func rateLimitKey(ctx context.Context, req any, by string) string {
+	switch by {
+	case RateLimitByApp:
+		if appID := requestAppID(req); appID != "" {
+			return "app:" + appID
+		}
+	case RateLimitBySubject:
+		if uid := subject(ctx); uid != "" {
+			return "subject:" + uid
+		}
+	}
+
+	if ip := clientip.IP(ctx); ip != "" {
+		return "ip:" + ip
+	}
+
+	return ""
+}
+
+// requestAppID returns the app of the requests that name one, as app_uuid
+// or as the client_id of the OAuth RPCs.
+func requestAppID(req any) string {
+	switch r := req.(type) {
+	case interface{ GetAppUuid() string }:
+		return strings.TrimSpace(r.GetAppUuid())
+	case interface{ GetClientId() string }:
+		return strings.TrimSpace(r.GetClientId())
+	}
+
+	return ""
+}
+
+func rateLimitError(retryAfter time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,88 @@
+package jobsapp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a maintenance task executed periodically in the background.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type App struct {
+	log    *slog.Logger
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, jobs ...Job) *App {
+	return &App{
+		log:  log,
+		jobs: jobs,
+	}
+}
+
+// Run starts every job in its own goroutine and returns immediately.
+func (a *App) Run() {
+	const op = "jobsapp.Run"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	for _, job := range a.jobs {
+		if job.Interval <= 0 {
+			a.log.Warn("job is disabled", slog.String("op", op), slog.String("job", job.Name))
+
+			continue
+		}
+
+		a.wg.Add(1)
+
+		go a.run(ctx, job)
+	}
+}
+
+func (a *App) run(ctx context.Context, job Job) {
+	const op = "jobsapp.run"
+
+	defer a.wg.Done()
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("job", job.Name),
+	)
+
+	log.Info("job is scheduled", slog.Duration("interval", job.Interval))
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil {
+				log.Error("job failed", slog.String("error:", err.Error()))
+			}
+		}
+	}
+}
+
+func (a *App) Stop() {
+	const op = "jobsapp.Stop"
+
+	a.log.With(slog.String("op", op)).Info("Stopping background jobs")
+
+	if a.cancel != nil {
+		a.cancel()
+	}
+
+	a.wg.Wait()
+}
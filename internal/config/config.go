@@ -2,6 +2,7 @@ package config
 
 import (
 	"flag"
+	"log/slog"
 	"os"
 	"time"
 
@@ -9,10 +10,179 @@ import (
 )
 
 type Config struct {
-	Env      string        `yaml:"env" env-default:"local"`
-	Storage  StorageConfig `yaml:"storage" env-required:"true"`
-	TokenTTL time.Duration `yaml:"token_ttl" env-required:"true"`
-	GRPC     GRPCConfig    `yaml:"grpc"`
+	Env             string               `yaml:"env" env-default:"local"`
+	Issuer          string               `yaml:"issuer" env-default:"https://localhost:8081"`
+	Storage         StorageConfig        `yaml:"storage" env-required:"true"`
+	TokenTTL        time.Duration        `yaml:"token_ttl" env-required:"true"`
+	RefreshTokenTTL time.Duration        `yaml:"refresh_token_ttl" env-default:"720h"`
+	GCInterval      time.Duration        `yaml:"gc_interval"`
+	GRPC            GRPCConfig           `yaml:"grpc"`
+	Signing         SigningConfig        `yaml:"signing"`
+	OAuth           OAuthConfig          `yaml:"oauth"`
+	Admin           AdminConfig          `yaml:"admin"`
+	Apps            AppsConfig           `yaml:"apps"`
+	Users           UsersConfig          `yaml:"users"`
+	Notify          NotifyConfig         `yaml:"notify"`
+	MFA             MFAConfig            `yaml:"mfa"`
+	WebAuthn        WebAuthnConfig       `yaml:"webauthn"`
+	Lockout         LockoutConfig        `yaml:"lockout"`
+	RateLimit       RateLimitConfig      `yaml:"rate_limit"`
+	PasswordPolicy  PasswordPolicyConfig `yaml:"password_policy"`
+
+	// RevokedTokensGCInterval is the former name of GCInterval, still read
+	// from older config files.
+	RevokedTokensGCInterval time.Duration `yaml:"revoked_tokens_gc_interval"`
+}
+
+// PasswordPolicyConfig is the password policy of the apps that do not
+// override it, see models.PasswordPolicy.
+type PasswordPolicyConfig struct {
+	MinLength int `yaml:"min_length" env-default:"8"`
+	// MaxLength of 0 only limits passwords to the 72 bytes bcrypt hashes.
+	MaxLength      int  `yaml:"max_length" env-default:"0"`
+	MinCharClasses int  `yaml:"min_char_classes" env-default:"0"`
+	DisallowEmail  bool `yaml:"disallow_email" env-default:"true"`
+	// MinStrength is from 0, which accepts any password, to 4.
+	MinStrength int `yaml:"min_strength" env-default:"2"`
+}
+
+// RateLimitConfig limits the rate of calls to the RPCs, both over gRPC and
+// through the gateway.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env-default:"true"`
+	// Backend keeps the state of the limits: "memory", where each replica
+	// enforces the limits on its own, or "postgres", where the replicas share
+	// them.
+	Backend string `yaml:"backend" env-default:"memory"`
+	// Rules limit the RPCs they name. Without rules DefaultRateLimitRules
+	// apply.
+	Rules []RateLimitRule `yaml:"rules"`
+}
+
+// RateLimitRule allows Requests calls of the RPC Method per Period, in
+// bursts of up to Burst calls (Requests by default).
+type RateLimitRule struct {
+	Method string `yaml:"method"`
+	// Key is what calls are counted by: "ip" of the client, "app" of the
+	// request or authenticated "subject". Calls without an app or a subject
+	// are counted by the client IP.
+	Key      string        `yaml:"key"`
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	Burst    int           `yaml:"burst"`
+}
+
+var DefaultRateLimitRules = []RateLimitRule{
+	{Method: "Register", Key: "ip", Requests: 10, Period: time.Minute, Burst: 20},
+	{Method: "Login", Key: "ip", Requests: 60, Period: time.Minute, Burst: 30},
+	{Method: "RegisterApp", Key: "subject", Requests: 30, Period: time.Minute, Burst: 10},
+	{Method: "Authorize", Key: "ip", Requests: 30, Period: time.Minute, Burst: 10},
+	{Method: "Token", Key: "ip", Requests: 60, Period: time.Minute, Burst: 30},
+	{Method: "VerifyMFA", Key: "ip", Requests: 30, Period: time.Minute, Burst: 10},
+	{Method: "VerifyDevice", Key: "subject", Requests: 30, Period: time.Minute, Burst: 10},
+	{Method: "StartEmailLogin", Key: "ip", Requests: 10, Period: time.Minute, Burst: 5},
+	{Method: "CompleteEmailLogin", Key: "ip", Requests: 30, Period: time.Minute, Burst: 10},
+}
+
+// LockoutConfig throttles failed logins, see auth.LockoutPolicy.
+type LockoutConfig struct {
+	// FailureWindow is how long failed logins are remembered after the last
+	// one.
+	FailureWindow time.Duration `yaml:"failure_window" env-default:"1h"`
+	// BackoffAfter is the number of failed logins of an account after which
+	// each attempt has to wait, starting with BackoffBase and doubling up to
+	// BackoffMax.
+	BackoffAfter int           `yaml:"backoff_after" env-default:"3"`
+	BackoffBase  time.Duration `yaml:"backoff_base" env-default:"1s"`
+	BackoffMax   time.Duration `yaml:"backoff_max" env-default:"5m"`
+	// Threshold is the number of failed logins that lock an account for
+	// Duration. 0 never locks accounts.
+	Threshold int           `yaml:"threshold" env-default:"10"`
+	Duration  time.Duration `yaml:"duration" env-default:"15m"`
+	// IPBackoffAfter is the BackoffAfter of client addresses. 0 does not
+	// throttle addresses, e.g. for tests run from one address.
+	IPBackoffAfter int `yaml:"ip_backoff_after" env-default:"50"`
+}
+
+type MFAConfig struct {
+	// TOTPIssuer names the service in authenticator apps.
+	TOTPIssuer string `yaml:"totp_issuer" env-default:"SSO"`
+	// ChallengeTTL is how long the code can be entered after the password
+	// of a user with MFA enabled is checked.
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" env-default:"5m"`
+}
+
+type WebAuthnConfig struct {
+	// SessionTTL is how long a registration or login ceremony can take
+	// between its begin and finish RPCs.
+	SessionTTL time.Duration `yaml:"session_ttl" env-default:"5m"`
+}
+
+// NotifyConfig configures how messages such as password reset tokens reach
+// users.
+type NotifyConfig struct {
+	// Backend is "smtp", or "file" for development. It has no default, so
+	// that tokens are never written to stdout by mistake.
+	Backend       string `yaml:"backend" env-required:"true"`
+	From          string `yaml:"from" env-default:"sso@localhost"`
+	DefaultLocale string `yaml:"default_locale" env-default:"en"`
+	// TemplatesDir holds templates overriding the built-in ones, see
+	// notify.Templates. Empty uses the built-in templates only.
+	TemplatesDir string           `yaml:"templates_dir"`
+	File         NotifyFileConfig `yaml:"file"`
+	SMTP         SMTPConfig       `yaml:"smtp"`
+}
+
+type NotifyFileConfig struct {
+	// Path is the file messages are appended to. Writing to stdout has to be
+	// asked for with "-", so that tokens do not end up in the logs by mistake.
+	Path string `yaml:"path"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	// Security is "starttls", "tls" or "none", see notify.SecurityStartTLS.
+	Security string `yaml:"security" env-default:"starttls"`
+}
+
+type UsersConfig struct {
+	// PasswordResetTTL is how long a password reset token can be used.
+	PasswordResetTTL time.Duration `yaml:"password_reset_ttl" env-default:"1h"`
+	// EmailVerificationTTL is how long an email verification token can be
+	// used.
+	EmailVerificationTTL time.Duration `yaml:"email_verification_ttl" env-default:"24h"`
+	// EmailLoginTTL is how long the code and the magic link of an email
+	// login can be used.
+	EmailLoginTTL time.Duration `yaml:"email_login_ttl" env-default:"15m"`
+	// EmailLoginURL is the page the magic link of an email login opens. The
+	// page completes the login with the token query parameter.
+	EmailLoginURL string `yaml:"email_login_url" env-default:"http://localhost:3000/login/email"`
+}
+
+type AppsConfig struct {
+	// SecretGracePeriod is how long the previous secret of an app is still
+	// accepted after the secret is rotated.
+	SecretGracePeriod time.Duration `yaml:"secret_grace_period" env-default:"24h"`
+	// SecretMasterKey is the base64 encoded 32 byte key that encrypts the
+	// secrets of apps at rest. Losing it makes HS256 apps unusable.
+	SecretMasterKey string `yaml:"secret_master_key" env:"SSO_SECRET_MASTER_KEY" env-required:"true"`
+}
+
+// AdminConfig is the administrator created on startup, who can then register
+// apps and manage the service. The password is only used to create the user.
+type AdminConfig struct {
+	Email    string `yaml:"email" env:"ADMIN_EMAIL"`
+	Password string `yaml:"password" env:"ADMIN_PASSWORD"`
+	AppID    string `yaml:"app_id" env-default:"sso-admin"`
+}
+
+type OAuthConfig struct {
+	AuthorizationCodeTTL time.Duration `yaml:"authorization_code_ttl" env-default:"1m"`
+	DeviceCodeTTL        time.Duration `yaml:"device_code_ttl" env-default:"10m"`
+	DevicePollInterval   time.Duration `yaml:"device_poll_interval" env-default:"5s"`
 }
 
 type GRPCConfig struct {
@@ -21,6 +191,17 @@ type GRPCConfig struct {
 	Timeout     time.Duration `yaml:"timeout"`
 }
 
+// SigningConfig configures the key ring used to sign tokens of apps with an
+// asymmetric signing algorithm. Key paths point to PEM encoded private keys
+// imported when the ring has no active key for the algorithm yet.
+type SigningConfig struct {
+	RSAKeyPath            string        `yaml:"rsa_key_path"`
+	ECDSAKeyPath          string        `yaml:"ecdsa_key_path"`
+	Ed25519KeyPath        string        `yaml:"ed25519_key_path"`
+	RotationPeriod        time.Duration `yaml:"rotation_period" env-default:"720h"`
+	RotationCheckInterval time.Duration `yaml:"rotation_check_interval" env-default:"1h"`
+}
+
 type StorageConfig struct {
 	Host     string `yaml:"host"`
 	User     string `yaml:"user"`
@@ -29,6 +210,29 @@ type StorageConfig struct {
 	Database string `yaml:"database"`
 }
 
+// redacted replaces secrets in logged configs.
+const redacted = "[REDACTED]"
+
+// redactedConfig is Config without the LogValue method, so that the
+// redacted copy is logged as is.
+type redactedConfig Config
+
+// LogValue logs the config with the passwords and keys it holds redacted.
+func (c Config) LogValue() slog.Value {
+	redact := func(secret *string) {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+
+	redact(&c.Storage.Password)
+	redact(&c.Apps.SecretMasterKey)
+	redact(&c.Admin.Password)
+	redact(&c.Notify.SMTP.Password)
+
+	return slog.AnyValue(redactedConfig(c))
+}
+
 func MustLoad() *Config {
 	path := fetchConfigPath()
 
@@ -39,6 +243,10 @@ func MustLoad() *Config {
 	return MustLoadByPath(path)
 }
 
+// defaultGCInterval applies when neither gc_interval nor its former name
+// revoked_tokens_gc_interval is set.
+const defaultGCInterval = 10 * time.Minute
+
 func MustLoadByPath(path string) *Config {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		panic("config file does not exist: " + path)
@@ -50,6 +258,14 @@ func MustLoadByPath(path string) *Config {
 		panic("failed to read config: " + err.Error())
 	}
 
+	if cfg.GCInterval == 0 {
+		cfg.GCInterval = cfg.RevokedTokensGCInterval
+	}
+
+	if cfg.GCInterval == 0 {
+		cfg.GCInterval = defaultGCInterval
+	}
+
 	return &cfg
 }
 
@@ -0,0 +1,41 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"sso/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_LogValueRedactsSecrets(t *testing.T) {
+	cfg := &config.Config{
+		Env:     "prod",
+		Storage: config.StorageConfig{User: "sso", Password: "storage-password"},
+		Apps:    config.AppsConfig{SecretMasterKey: "master-key"},
+		Admin:   config.AdminConfig{Email: "admin@example.com", Password: "admin-password"},
+		Notify:  config.NotifyConfig{SMTP: config.SMTPConfig{Password: "smtp-password"}},
+	}
+
+	for _, handler := range []func(*bytes.Buffer) slog.Handler{
+		func(buf *bytes.Buffer) slog.Handler { return slog.NewTextHandler(buf, nil) },
+		func(buf *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(buf, nil) },
+	} {
+		var buf bytes.Buffer
+
+		slog.New(handler(&buf)).Info("starting application", slog.Any("env", cfg))
+
+		out := buf.String()
+		assert.Contains(t, out, "admin@example.com")
+		assert.Contains(t, out, "[REDACTED]")
+
+		for _, secret := range []string{"storage-password", "master-key", "admin-password", "smtp-password"} {
+			assert.NotContains(t, out, secret)
+		}
+	}
+
+	// The config itself keeps the secrets.
+	assert.Equal(t, "master-key", cfg.Apps.SecretMasterKey)
+}
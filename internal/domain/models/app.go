@@ -1,10 +1,95 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type App struct {
 	gorm.Model
-	ID     string `gorm:"primaryKey"`
-	Name   string `gorm:"unique"`
-	Secret string
+	ID   string `gorm:"primaryKey"`
+	Name string `gorm:"unique"`
+	// Secret is the plaintext secret, which is never stored. Clients are
+	// authenticated against SecretHash, and SecretCiphertext is decrypted
+	// into Secret to sign and verify HS256 tokens.
+	Secret           string `gorm:"-"`
+	SecretHash       string
+	SecretCiphertext string
+	SigningAlgorithm string   `gorm:"default:ES256"`
+	RedirectURIs     []string `gorm:"serializer:json"`
+	AllowedScopes    []string `gorm:"serializer:json"`
+	// RequireEmailVerification blocks sign-in to the app until the user has
+	// verified the email.
+	RequireEmailVerification bool `gorm:"default:false"`
+	// WebAuthnRPID is the relying party ID, a domain such as example.com,
+	// that WebAuthn credentials for the app are scoped to. Empty disables
+	// WebAuthn for the app.
+	WebAuthnRPID string `gorm:"column:webauthn_rp_id"`
+	// WebAuthnOrigins are the origins allowed to run WebAuthn ceremonies for
+	// the app. Only https://<WebAuthnRPID> is allowed when empty.
+	WebAuthnOrigins []string `gorm:"column:webauthn_origins;serializer:json"`
+	// PublicClient marks an app that can not keep its secret, such as a
+	// browser or mobile app. Public clients are not authenticated at the
+	// token endpoint, so their codes are protected by PKCE alone.
+	PublicClient bool `gorm:"default:false"`
+	// PasswordPolicy overrides the default password policy for the app.
+	PasswordPolicy *PasswordPolicy `gorm:"serializer:json"`
+	// PreviousSecret is the secret replaced by the last rotation. It is
+	// accepted until PreviousSecretExpiresAt, so clients can switch over.
+	PreviousSecret           string `gorm:"-"`
+	PreviousSecretHash       string
+	PreviousSecretCiphertext string
+	PreviousSecretExpiresAt  *time.Time
+}
+
+// Secrets returns the secrets the app is accepted with at now: the current
+// one and, during the grace period of a rotation, the previous one.
+func (a App) Secrets(now time.Time) []string {
+	secrets := []string{a.Secret}
+
+	if a.PreviousSecret != "" && a.InGracePeriod(now) {
+		secrets = append(secrets, a.PreviousSecret)
+	}
+
+	return secrets
+}
+
+// SecretHashes is Secrets for the hashes of the secrets.
+func (a App) SecretHashes(now time.Time) []string {
+	hashes := []string{a.SecretHash}
+
+	if a.PreviousSecretHash != "" && a.InGracePeriod(now) {
+		hashes = append(hashes, a.PreviousSecretHash)
+	}
+
+	return hashes
+}
+
+// InGracePeriod reports whether the previous secret is still accepted at now.
+func (a App) InGracePeriod(now time.Time) bool {
+	return a.PreviousSecretExpiresAt != nil && now.Before(*a.PreviousSecretExpiresAt)
+}
+
+// AppFilter selects the apps to list.
+type AppFilter struct {
+	// After is the position of the last app of the previous page.
+	After *Cursor
+	Limit int
+}
+
+// AppUpdate holds the fields of an app to change. Nil fields are left as
+// they are.
+type AppUpdate struct {
+	Name                     *string
+	RedirectURIs             *[]string
+	AllowedScopes            *[]string
+	RequireEmailVerification *bool
+	WebAuthnRPID             *string
+	WebAuthnOrigins          *[]string
+	SigningAlgorithm         *string
+	PasswordPolicy           *PasswordPolicy
+	// ResetPasswordPolicy drops the password policy of the app, which then
+	// uses the default one.
+	ResetPasswordPolicy bool
 }
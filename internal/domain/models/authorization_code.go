@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// AuthorizationCode is a single-use code of the OAuth 2.0 authorization code
+// grant. Only the hash of the code is stored. AccessTokenID is the jti of the
+// access token issued for the code, kept to revoke it if the code is reused.
+type AuthorizationCode struct {
+	CodeHash            string `gorm:"primaryKey"`
+	AppID               string `gorm:"not null"`
+	UserID              string `gorm:"not null"`
+	RedirectURI         string `gorm:"not null"`
+	Scope               string
+	Nonce               string
+	CodeChallenge       string    `gorm:"not null"`
+	CodeChallengeMethod string    `gorm:"not null"`
+	FamilyID            string    `gorm:"not null"`
+	ExpiresAt           time.Time `gorm:"index; not null"`
+	UsedAt              *time.Time
+	AccessTokenID       string
+	CreatedAt           time.Time
+}
+
+// AuthorizationRequest holds the parameters of an OAuth 2.0 authorization
+// request.
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
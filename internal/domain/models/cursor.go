@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Cursor is a position in a list ordered by creation time. The id breaks
+// ties between entries created at the same time.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
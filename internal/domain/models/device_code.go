@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	DeviceCodePending  = "pending"
+	DeviceCodeApproved = "approved"
+	DeviceCodeDenied   = "denied"
+)
+
+// DeviceCode is a pending authorization of the OAuth 2.0 device
+// authorization grant (RFC 8628). The device polls with the device code,
+// of which only the hash is stored, while the user approves the request by
+// entering the user code.
+type DeviceCode struct {
+	DeviceCodeHash string `gorm:"primaryKey"`
+	UserCode       string `gorm:"uniqueIndex; not null"`
+	AppID          string `gorm:"not null"`
+	Scope          string
+	Status         string `gorm:"not null"`
+	UserID         string
+	Interval       time.Duration `gorm:"not null"`
+	LastPolledAt   *time.Time
+	ExpiresAt      time.Time `gorm:"index; not null"`
+	UsedAt         *time.Time
+	CreatedAt      time.Time
+}
+
+// DeviceAuthorization is the response of the device authorization endpoint.
+type DeviceAuthorization struct {
+	DeviceCode              string
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string
+	ExpiresIn               time.Duration
+	Interval                time.Duration
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// EmailLogin is a pending passwordless login to an app. The user completes
+// it with the numeric code or the magic link sent to the email. Only hashes
+// of the login ID, which the magic link carries, and of the code are stored.
+type EmailLogin struct {
+	IDHash   string `gorm:"primaryKey"`
+	UserID   string `gorm:"index:idx_email_login_user_app; not null"`
+	AppID    string `gorm:"index:idx_email_login_user_app; not null"`
+	CodeHash string `gorm:"not null"`
+	Nonce    string
+	// Attempts is the number of wrong codes entered for the login.
+	Attempts  int       `gorm:"not null; default:0"`
+	ExpiresAt time.Time `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// EmailVerificationToken is a single-use token sent to the email of the user
+// to prove that the user owns it. It verifies only the email it was sent to.
+// Only the hash of the token is stored.
+type EmailVerificationToken struct {
+	TokenHash string    `gorm:"primaryKey"`
+	UserID    string    `gorm:"index; not null"`
+	AppID     string    `gorm:"not null"`
+	Email     string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// LoginThrottle counts the recent failed logins of an account or of a client
+// address, or other throttled attempts like email logins, identified by Key.
+type LoginThrottle struct {
+	Key string `gorm:"primaryKey"`
+	// Failures is the number of failed logins since the first one within the
+	// failure window.
+	Failures      int       `gorm:"not null; default:0"`
+	LastFailureAt time.Time `gorm:"index; not null"`
+	// LockedUntil is set when the account is locked out.
+	LockedUntil *time.Time
+}
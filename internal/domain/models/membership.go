@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Membership links a user identity to an app the user is registered in. One
+// identity may be a member of many apps.
+type Membership struct {
+	UserID    string `gorm:"primaryKey"`
+	AppID     string `gorm:"primaryKey"`
+	User      User   `gorm:"foreignKey:UserID; constraint:OnDelete:CASCADE"`
+	App       App    `gorm:"foreignKey:AppID; constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// MFAChallenge is the pending second step of a login by a user with MFA
+// enabled. It is created once the password is checked and is completed with
+// a code by VerifyMFA. Only the hash of the challenge ID is stored.
+type MFAChallenge struct {
+	IDHash string `gorm:"primaryKey"`
+	UserID string `gorm:"not null"`
+	AppID  string `gorm:"not null"`
+	Nonce  string
+	// Attempts is the number of wrong codes entered for the challenge.
+	Attempts  int       `gorm:"not null; default:0"`
+	ExpiresAt time.Time `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+// PasswordPolicy is what new passwords of users must satisfy. The zero value
+// accepts any password bcrypt can hash.
+type PasswordPolicy struct {
+	// MinLength and MaxLength count characters. Passwords are also limited
+	// to the 72 bytes bcrypt hashes whatever MaxLength is.
+	MinLength int `json:"min_length"`
+	MaxLength int `json:"max_length"`
+	// MinCharClasses is how many of lowercase letters, uppercase letters,
+	// digits and other characters the password must mix.
+	MinCharClasses int `json:"min_char_classes"`
+	// DisallowEmail rejects passwords made of the email of the user.
+	DisallowEmail bool `json:"disallow_email"`
+	// MinStrength is the lowest estimated strength accepted, from 0 (too
+	// guessable) to 4 (very unguessable).
+	MinStrength int `json:"min_strength"`
+}
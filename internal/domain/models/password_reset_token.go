@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use token that lets the user set a new
+// password without knowing the current one. Only the hash of the token is
+// stored.
+type PasswordResetToken struct {
+	TokenHash string    `gorm:"primaryKey"`
+	UserID    string    `gorm:"index; not null"`
+	AppID     string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
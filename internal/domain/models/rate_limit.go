@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RateLimitBucket is the token bucket of a rate limit key shared by the
+// replicas of the service.
+type RateLimitBucket struct {
+	Key        string    `gorm:"primaryKey"`
+	Tokens     float64   `gorm:"not null"`
+	RefilledAt time.Time `gorm:"index; not null"`
+}
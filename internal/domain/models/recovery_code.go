@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RecoveryCode is a single-use code that completes the MFA step of a login
+// when the authenticator app is lost. Only the hash of the code is stored.
+type RecoveryCode struct {
+	CodeHash  string `gorm:"primaryKey"`
+	UserID    string `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
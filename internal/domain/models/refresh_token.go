@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshToken struct {
+	gorm.Model
+	ID        string    `gorm:"primaryKey"`
+	TokenHash string    `gorm:"uniqueIndex; not null"`
+	FamilyID  string    `gorm:"index; not null"`
+	UserID    string    `gorm:"not null"`
+	AppID     string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// Tokens is the set of tokens handed out to a client after a successful
+// authentication.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	Scope        string
+	ExpiresIn    time.Duration
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RevokedToken is a denylist entry for an access token that was revoked
+// before its expiry. Entries are kept until ExpiresAt, after which the
+// token is rejected on its own.
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"index; not null"`
+	CreatedAt time.Time
+}
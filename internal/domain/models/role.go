@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Role is a named set of permissions defined by an app. Roles and
+// permissions of different apps are independent of each other.
+type Role struct {
+	ID          string       `gorm:"primaryKey"`
+	AppID       string       `gorm:"not null; uniqueIndex:idx_roles_app_name"`
+	Name        string       `gorm:"not null; uniqueIndex:idx_roles_app_name"`
+	App         App          `gorm:"foreignKey:AppID; constraint:OnDelete:CASCADE"`
+	Permissions []Permission `gorm:"many2many:role_permissions; constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+}
+
+// Permission is an action within an app, such as "orders:write", that roles
+// grant to their users.
+type Permission struct {
+	ID        string `gorm:"primaryKey"`
+	AppID     string `gorm:"not null; uniqueIndex:idx_permissions_app_name"`
+	Name      string `gorm:"not null; uniqueIndex:idx_permissions_app_name"`
+	App       App    `gorm:"foreignKey:AppID; constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
+
+// UserRole assigns a role to a user.
+type UserRole struct {
+	UserID    string `gorm:"primaryKey"`
+	RoleID    string `gorm:"primaryKey"`
+	User      User   `gorm:"foreignKey:UserID; constraint:OnDelete:CASCADE"`
+	Role      Role   `gorm:"foreignKey:RoleID; constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SigningKey is a server key pair of the key ring. Only one key per
+// algorithm is active; retired keys are kept for verification until every
+// token signed with them has expired.
+type SigningKey struct {
+	ID        string `gorm:"primaryKey"`
+	Algorithm string `gorm:"index; not null"`
+	// PrivateKeyCiphertext is the PEM encoded private key encrypted with the
+	// master key, like the secrets of apps.
+	PrivateKeyCiphertext string
+	CreatedAt            time.Time
+	RetiredAt            *time.Time `gorm:"index"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
@@ -8,6 +12,39 @@ type User struct {
 	Email    string `gorm:"unique; not null"`
 	Passhash []byte `gorm:"not null"`
 	IsAdmin  bool   `gorm:"default:false"`
-	AppID    string `gorm:"not null"`
-	App      App    `gorm:"foreignKey:AppID"`
+	// EmailVerified is set once the user proves to own the email. Changing
+	// the email clears it.
+	EmailVerified bool `gorm:"default:false"`
+	// TOTPSecretCiphertext is the sealed TOTP secret. It is written on
+	// enrollment and takes effect once the enrollment is confirmed, which
+	// sets TOTPEnabled.
+	TOTPSecretCiphertext string
+	TOTPEnabled          bool `gorm:"default:false"`
+	// TOTPLastStep is the time step of the last accepted code, so that a
+	// code can not be used twice.
+	TOTPLastStep int64 `gorm:"default:0"`
+	// TokenVersion is put into access tokens and incremented when the
+	// password changes, which invalidates the access tokens issued before.
+	TokenVersion int `gorm:"not null; default:0"`
+	// AppIDs are the apps the user is a member of. They are loaded only
+	// where needed and are not stored with the user.
+	AppIDs []string `gorm:"-"`
+}
+
+// UserFilter selects the users to list. Zero fields match every user.
+type UserFilter struct {
+	AppID         string
+	EmailPrefix   string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// After is the position of the last user of the previous page.
+	After *Cursor
+	Limit int
+}
+
+// UserUpdate holds the fields of a user to change. Nil fields are left as
+// they are.
+type UserUpdate struct {
+	Email   *string
+	IsAdmin *bool
 }
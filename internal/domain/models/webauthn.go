@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// WebAuthnCredential is a public key credential (security key or passkey)
+// registered by a user for the relying party of an app.
+type WebAuthnCredential struct {
+	// ID is the base64url encoded credential ID chosen by the
+	// authenticator.
+	ID     string `gorm:"primaryKey"`
+	UserID string `gorm:"index; not null"`
+	RPID   string `gorm:"column:rp_id; not null"`
+	Name   string
+	// PublicKey is the COSE_Key of the credential.
+	PublicKey []byte `gorm:"not null"`
+	// SignCount is the last signature counter reported by the
+	// authenticator. A counter that does not increase indicates a cloned
+	// authenticator.
+	SignCount  uint32
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// WebAuthn ceremonies of a WebAuthnSession.
+const (
+	WebAuthnRegistration = "registration"
+	WebAuthnLogin        = "login"
+)
+
+// WebAuthnSession holds the challenge of a ceremony between its begin and
+// finish RPCs. Only the hash of the session ID is stored.
+type WebAuthnSession struct {
+	IDHash    string `gorm:"primaryKey"`
+	Ceremony  string `gorm:"not null"`
+	Challenge string `gorm:"not null"`
+	// UserID is empty for a login, where the user picks a passkey.
+	UserID    string
+	AppID     string `gorm:"not null"`
+	Nonce     string
+	ExpiresAt time.Time `gorm:"index; not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
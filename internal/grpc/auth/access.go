@@ -0,0 +1,65 @@
+package authgrpc
+
+import ssov1 "sso/streaming/go/sso"
+
+// Access is the authorization an RPC requires from its caller.
+type Access int
+
+const (
+	// AccessPublic RPCs are open to anyone; they authenticate the caller
+	// themselves, if at all.
+	AccessPublic Access = iota
+	// AccessUser RPCs require a valid access token of a user.
+	AccessUser
+	// AccessAdmin RPCs require a valid access token of an admin user.
+	AccessAdmin
+)
+
+// Rules is the access required by every RPC of the Auth service. RPCs that
+// are missing from the table are denied.
+var Rules = map[string]Access{
+	ssov1.Auth_Register_FullMethodName:                   AccessPublic,
+	ssov1.Auth_Login_FullMethodName:                      AccessPublic,
+	ssov1.Auth_Refresh_FullMethodName:                    AccessPublic,
+	ssov1.Auth_Logout_FullMethodName:                     AccessPublic,
+	ssov1.Auth_Introspect_FullMethodName:                 AccessPublic,
+	ssov1.Auth_GetJWKS_FullMethodName:                    AccessPublic,
+	ssov1.Auth_GetOpenIDConfiguration_FullMethodName:     AccessPublic,
+	ssov1.Auth_Authorize_FullMethodName:                  AccessPublic,
+	ssov1.Auth_Token_FullMethodName:                      AccessPublic,
+	ssov1.Auth_DeviceAuthorize_FullMethodName:            AccessPublic,
+	ssov1.Auth_RequestPasswordReset_FullMethodName:       AccessPublic,
+	ssov1.Auth_ConfirmPasswordReset_FullMethodName:       AccessPublic,
+	ssov1.Auth_VerifyEmail_FullMethodName:                AccessPublic,
+	ssov1.Auth_RequestEmailVerification_FullMethodName:   AccessPublic,
+	ssov1.Auth_VerifyMFA_FullMethodName:                  AccessPublic,
+	ssov1.Auth_BeginWebAuthnLogin_FullMethodName:         AccessPublic,
+	ssov1.Auth_FinishWebAuthnLogin_FullMethodName:        AccessPublic,
+	ssov1.Auth_StartEmailLogin_FullMethodName:            AccessPublic,
+	ssov1.Auth_CompleteEmailLogin_FullMethodName:         AccessPublic,
+	ssov1.Auth_CheckPermission_FullMethodName:            AccessPublic,
+	ssov1.Auth_UserInfo_FullMethodName:                   AccessUser,
+	ssov1.Auth_VerifyDevice_FullMethodName:               AccessUser,
+	ssov1.Auth_ChangePassword_FullMethodName:             AccessUser,
+	ssov1.Auth_EnrollTOTP_FullMethodName:                 AccessUser,
+	ssov1.Auth_ConfirmTOTP_FullMethodName:                AccessUser,
+	ssov1.Auth_RegenerateRecoveryCodes_FullMethodName:    AccessUser,
+	ssov1.Auth_BeginWebAuthnRegistration_FullMethodName:  AccessUser,
+	ssov1.Auth_FinishWebAuthnRegistration_FullMethodName: AccessUser,
+	ssov1.Auth_IsAdmin_FullMethodName:                    AccessAdmin,
+	ssov1.Auth_RegisterApp_FullMethodName:                AccessAdmin,
+	ssov1.Auth_GetApp_FullMethodName:                     AccessAdmin,
+	ssov1.Auth_ListApps_FullMethodName:                   AccessAdmin,
+	ssov1.Auth_UpdateApp_FullMethodName:                  AccessAdmin,
+	ssov1.Auth_DeleteApp_FullMethodName:                  AccessAdmin,
+	ssov1.Auth_RotateAppSecret_FullMethodName:            AccessAdmin,
+	ssov1.Auth_RotateSigningKeys_FullMethodName:          AccessAdmin,
+	ssov1.Auth_CreateRole_FullMethodName:                 AccessAdmin,
+	ssov1.Auth_AddRolePermission_FullMethodName:          AccessAdmin,
+	ssov1.Auth_AssignRole_FullMethodName:                 AccessAdmin,
+	ssov1.Auth_GetUser_FullMethodName:                    AccessAdmin,
+	ssov1.Auth_ListUsers_FullMethodName:                  AccessAdmin,
+	ssov1.Auth_UpdateUser_FullMethodName:                 AccessAdmin,
+	ssov1.Auth_DeleteUser_FullMethodName:                 AccessAdmin,
+	ssov1.Auth_UnlockUser_FullMethodName:                 AccessAdmin,
+}
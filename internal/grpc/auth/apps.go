@@ -0,0 +1,204 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetApp(
+	ctx context.Context,
+	req *ssov1.GetAppRequest,
+) (*ssov1.GetAppResponse, error) {
+
+	if req.GetAppUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	app, err := s.auth.GetApp(ctx, req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrAppNotFound) {
+			return nil, status.Error(codes.NotFound, "app not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.GetAppResponse{
+		App: appToProto(app),
+	}, nil
+}
+
+func (s *serverAPI) ListApps(
+	ctx context.Context,
+	req *ssov1.ListAppsRequest,
+) (*ssov1.ListAppsResponse, error) {
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	apps, nextPageToken, err := s.auth.ListApps(ctx, int(req.GetPageSize()), req.GetPageToken())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	resp := &ssov1.ListAppsResponse{
+		Apps:          make([]*ssov1.App, 0, len(apps)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, app := range apps {
+		resp.Apps = append(resp.Apps, appToProto(app))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) UpdateApp(
+	ctx context.Context,
+	req *ssov1.UpdateAppRequest,
+) (*ssov1.UpdateAppResponse, error) {
+
+	if req.GetAppUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	if req.GetPasswordPolicy() != nil && req.GetResetPasswordPolicy() {
+		return nil, status.Error(codes.InvalidArgument, "either password_policy or reset_password_policy is allowed")
+	}
+
+	update := models.AppUpdate{
+		Name:                     req.Name,
+		RequireEmailVerification: req.RequireEmailVerification,
+		WebAuthnRPID:             req.WebauthnRpId,
+		PasswordPolicy:           passwordPolicyFromProto(req.GetPasswordPolicy()),
+		ResetPasswordPolicy:      req.GetResetPasswordPolicy(),
+	}
+
+	if req.GetRedirectUris() != nil {
+		redirectURIs := req.GetRedirectUris().GetValues()
+		update.RedirectURIs = &redirectURIs
+	}
+
+	if req.GetAllowedScopes() != nil {
+		allowedScopes := req.GetAllowedScopes().GetValues()
+		update.AllowedScopes = &allowedScopes
+	}
+
+	if req.GetWebauthnOrigins() != nil {
+		webAuthnOrigins := req.GetWebauthnOrigins().GetValues()
+		update.WebAuthnOrigins = &webAuthnOrigins
+	}
+
+	app, err := s.auth.UpdateApp(ctx, req.GetAppUuid(), update)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrAppNotFound):
+			return nil, status.Error(codes.NotFound, "app not found")
+		case errors.Is(err, auth.ErrAppExists):
+			return nil, status.Error(codes.AlreadyExists, "app name is already taken")
+		case errors.Is(err, auth.ErrInvalidAppName):
+			return nil, status.Error(codes.InvalidArgument, "invalid name")
+		case errors.Is(err, auth.ErrInvalidRedirectURI):
+			return nil, status.Error(codes.InvalidArgument, "invalid redirect_uris")
+		case errors.Is(err, auth.ErrInvalidScope):
+			return nil, status.Error(codes.InvalidArgument, "invalid allowed_scopes")
+		case errors.Is(err, auth.ErrInvalidWebAuthnRPID):
+			return nil, status.Error(codes.InvalidArgument, "invalid webauthn_rp_id")
+		case errors.Is(err, auth.ErrInvalidWebAuthnOrigin):
+			return nil, status.Error(codes.InvalidArgument, "invalid webauthn_origins")
+		case errors.Is(err, auth.ErrInvalidPasswordPolicy):
+			return nil, status.Error(codes.InvalidArgument, "invalid password_policy")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.UpdateAppResponse{
+		App: appToProto(app),
+	}, nil
+}
+
+func (s *serverAPI) DeleteApp(
+	ctx context.Context,
+	req *ssov1.DeleteAppRequest,
+) (*ssov1.DeleteAppResponse, error) {
+
+	if req.GetAppUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	err := s.auth.DeleteApp(ctx, req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrAppNotFound) {
+			return nil, status.Error(codes.NotFound, "app not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.DeleteAppResponse{}, nil
+}
+
+func (s *serverAPI) RotateAppSecret(
+	ctx context.Context,
+	req *ssov1.RotateAppSecretRequest,
+) (*ssov1.RotateAppSecretResponse, error) {
+
+	if req.GetAppUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	secret, previousExpiresAt, err := s.auth.RotateAppSecret(ctx, req.GetAppUuid(), req.GetSecret(), req.GetRevokePrevious())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrAppNotFound) {
+			return nil, status.Error(codes.NotFound, "app not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	resp := &ssov1.RotateAppSecretResponse{
+		Secret: secret,
+	}
+
+	if !previousExpiresAt.IsZero() {
+		resp.PreviousSecretExpiresAt = previousExpiresAt.Unix()
+	}
+
+	return resp, nil
+}
+
+func appToProto(app models.App) *ssov1.App {
+	resp := &ssov1.App{
+		AppUuid:                  app.ID,
+		Name:                     app.Name,
+		SigningAlgorithm:         app.SigningAlgorithm,
+		RedirectUris:             app.RedirectURIs,
+		AllowedScopes:            app.AllowedScopes,
+		CreatedAt:                app.CreatedAt.Unix(),
+		UpdatedAt:                app.UpdatedAt.Unix(),
+		RequireEmailVerification: app.RequireEmailVerification,
+		WebauthnRpId:             app.WebAuthnRPID,
+		WebauthnOrigins:          app.WebAuthnOrigins,
+		PasswordPolicy:           passwordPolicyToProto(app.PasswordPolicy),
+		PublicClient:             app.PublicClient,
+	}
+
+	if app.InGracePeriod(time.Now()) {
+		resp.PreviousSecretExpiresAt = app.PreviousSecretExpiresAt.Unix()
+	}
+
+	return resp
+}
@@ -0,0 +1,72 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) DeviceAuthorize(
+	ctx context.Context,
+	req *ssov1.DeviceAuthorizeRequest,
+) (*ssov1.DeviceAuthorizeResponse, error) {
+
+	if req.GetClientId() == "" {
+		return nil, oauthError(codes.InvalidArgument, oauthInvalidRequest, "client_id is required")
+	}
+
+	authorization, err := s.auth.DeviceAuthorize(ctx, req.GetClientId(), req.GetClientSecret(), req.GetScope())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidClient):
+			return nil, oauthError(codes.Unauthenticated, oauthInvalidClient, "client authentication failed")
+		case errors.Is(err, auth.ErrInvalidScope):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidScope, "unsupported scope")
+		}
+		return nil, oauthError(codes.Internal, oauthServerError, "internal error")
+	}
+
+	return &ssov1.DeviceAuthorizeResponse{
+		DeviceCode:              authorization.DeviceCode,
+		UserCode:                authorization.UserCode,
+		VerificationUri:         authorization.VerificationURI,
+		VerificationUriComplete: authorization.VerificationURIComplete,
+		ExpiresIn:               int64(authorization.ExpiresIn.Seconds()),
+		Interval:                int64(authorization.Interval.Seconds()),
+	}, nil
+}
+
+func (s *serverAPI) VerifyDevice(
+	ctx context.Context,
+	req *ssov1.VerifyDeviceRequest,
+) (*ssov1.VerifyDeviceResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUserCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_code is required")
+	}
+
+	err = s.auth.VerifyDevice(ctx, token, req.GetUserCode(), req.GetApprove())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrInvalidUserCode):
+			return nil, status.Error(codes.NotFound, "user code not found or expired")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.VerifyDeviceResponse{}, nil
+}
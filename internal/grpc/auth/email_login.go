@@ -0,0 +1,117 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) StartEmailLogin(
+	ctx context.Context,
+	req *ssov1.StartEmailLoginRequest,
+) (*ssov1.StartEmailLoginResponse, error) {
+
+	if err := validateStartEmailLogin(req); err != nil {
+		return nil, err
+	}
+
+	err := s.auth.StartEmailLogin(ctx, req.GetEmail(), req.GetAppUuid(), req.GetNonce())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidAppID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		}
+		if errors.Is(err, auth.ErrTooManyAttempts) {
+			return nil, throttledError(codes.ResourceExhausted, "too many email logins", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.StartEmailLoginResponse{}, nil
+}
+
+func (s *serverAPI) CompleteEmailLogin(
+	ctx context.Context,
+	req *ssov1.CompleteEmailLoginRequest,
+) (*ssov1.LoginResponse, error) {
+
+	if err := validateCompleteEmailLogin(req); err != nil {
+		return nil, err
+	}
+
+	tokens, mfaChallengeID, err := s.auth.CompleteEmailLogin(
+		ctx,
+		req.GetEmail(),
+		req.GetAppUuid(),
+		req.GetCode(),
+		req.GetToken(),
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidEmailLoginCode):
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired code")
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		case errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid credentials")
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			return nil, status.Error(codes.FailedPrecondition, "email is not verified")
+		case errors.Is(err, auth.ErrTooManyAttempts):
+			return nil, throttledError(codes.ResourceExhausted, "too many attempts", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if mfaChallengeID != "" {
+		return &ssov1.LoginResponse{MfaChallengeId: mfaChallengeID}, nil
+	}
+
+	return &ssov1.LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		IdToken:      tokens.IDToken,
+	}, nil
+}
+
+func validateStartEmailLogin(req *ssov1.StartEmailLoginRequest) error {
+	if req.GetEmail() == "" {
+		return status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
+
+func validateCompleteEmailLogin(req *ssov1.CompleteEmailLoginRequest) error {
+	if req.GetToken() != "" {
+		if req.GetCode() != "" {
+			return status.Error(codes.InvalidArgument, "either code or token is allowed")
+		}
+
+		return nil
+	}
+
+	if req.GetCode() == "" {
+		return status.Error(codes.InvalidArgument, "code or token is required")
+	}
+
+	if req.GetEmail() == "" {
+		return status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
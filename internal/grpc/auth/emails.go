@@ -0,0 +1,65 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) VerifyEmail(
+	ctx context.Context,
+	req *ssov1.VerifyEmailRequest,
+) (*ssov1.VerifyEmailResponse, error) {
+
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	err := s.auth.VerifyEmail(ctx, req.GetToken())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.VerifyEmailResponse{}, nil
+}
+
+func (s *serverAPI) RequestEmailVerification(
+	ctx context.Context,
+	req *ssov1.RequestEmailVerificationRequest,
+) (*ssov1.RequestEmailVerificationResponse, error) {
+
+	if err := validateRequestEmailVerification(req); err != nil {
+		return nil, err
+	}
+
+	err := s.auth.RequestEmailVerification(ctx, req.GetEmail(), req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidAppID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.RequestEmailVerificationResponse{}, nil
+}
+
+func validateRequestEmailVerification(req *ssov1.RequestEmailVerificationRequest) error {
+	if req.GetEmail() == "" {
+		return status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
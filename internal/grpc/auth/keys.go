@@ -0,0 +1,84 @@
+package authgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sso/internal/lib/jwk"
+	"sso/internal/lib/jwt"
+	"sso/internal/services/keys"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetJWKS(
+	ctx context.Context,
+	req *ssov1.GetJWKSRequest,
+) (*httpbody.HttpBody, error) {
+
+	keys, err := s.keys.PublicKeys(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	set := jwk.Set{Keys: make([]jwk.JWK, 0, len(keys))}
+
+	for _, key := range keys {
+		publicKey, err := jwk.New(key.ID, key.Algorithm, key.Public())
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		set.Keys = append(set.Keys, publicKey)
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+func (s *serverAPI) RotateSigningKeys(
+	ctx context.Context,
+	req *ssov1.RotateSigningKeysRequest,
+) (*ssov1.RotateSigningKeysResponse, error) {
+
+	err := validateRotateSigningKeys(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.keys.Rotate(ctx, req.GetAlgorithm())
+	if err != nil {
+		if errors.Is(err, keys.ErrUnsupportedAlgorithm) {
+			return nil, status.Error(codes.InvalidArgument, "unsupported algorithm")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	kids := make([]string, 0, len(rotated))
+	for _, key := range rotated {
+		kids = append(kids, key.ID)
+	}
+
+	return &ssov1.RotateSigningKeysResponse{
+		Kids: kids,
+	}, nil
+}
+
+func validateRotateSigningKeys(req *ssov1.RotateSigningKeysRequest) error {
+	if alg := req.GetAlgorithm(); alg != "" && !jwt.IsAsymmetricAlgorithm(alg) {
+		return status.Error(codes.InvalidArgument, "unsupported algorithm")
+	}
+
+	return nil
+}
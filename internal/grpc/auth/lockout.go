@@ -0,0 +1,52 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (s *serverAPI) UnlockUser(
+	ctx context.Context,
+	req *ssov1.UnlockUserRequest,
+) (*ssov1.UnlockUserResponse, error) {
+
+	if req.GetUserUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	err := s.auth.UnlockUser(ctx, req.GetUserUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.UnlockUserResponse{}, nil
+}
+
+// throttledError builds the status of a login refused by the lockout policy,
+// telling the client when to try again.
+func throttledError(code codes.Code, message string, err error) error {
+	var throttled *auth.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return status.Error(code, message)
+	}
+
+	st, detailsErr := status.New(code, message).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(throttled.RetryAfter),
+	})
+	if detailsErr != nil {
+		return status.Error(code, message)
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,156 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) EnrollTOTP(
+	ctx context.Context,
+	req *ssov1.EnrollTOTPRequest,
+) (*ssov1.EnrollTOTPResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, recoveryCodes, err := s.auth.EnrollTOTP(ctx, token)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrMFAAlreadyEnabled):
+			return nil, status.Error(codes.FailedPrecondition, "mfa is already enabled")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.EnrollTOTPResponse{
+		Secret:        secret,
+		OtpauthUri:    uri,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *serverAPI) ConfirmTOTP(
+	ctx context.Context,
+	req *ssov1.ConfirmTOTPRequest,
+) (*ssov1.ConfirmTOTPResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	err = s.auth.ConfirmTOTP(ctx, token, req.GetCode())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			return nil, status.Error(codes.InvalidArgument, "invalid code")
+		case errors.Is(err, auth.ErrMFAAlreadyEnabled):
+			return nil, status.Error(codes.FailedPrecondition, "mfa is already enabled")
+		case errors.Is(err, auth.ErrMFANotEnrolled):
+			return nil, status.Error(codes.FailedPrecondition, "totp enrollment is not started")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.ConfirmTOTPResponse{}, nil
+}
+
+func (s *serverAPI) VerifyMFA(
+	ctx context.Context,
+	req *ssov1.VerifyMFARequest,
+) (*ssov1.VerifyMFAResponse, error) {
+
+	if err := validateVerifyMFA(req); err != nil {
+		return nil, err
+	}
+
+	tokens, remaining, err := s.auth.VerifyMFA(ctx, req.GetMfaChallengeId(), req.GetCode())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired mfa_challenge_id")
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			return nil, status.Error(codes.InvalidArgument, "invalid code")
+		case errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		case errors.Is(err, auth.ErrTooManyAttempts):
+			return nil, throttledError(codes.ResourceExhausted, "too many login attempts", err)
+		case errors.Is(err, auth.ErrAccountLocked):
+			return nil, throttledError(codes.PermissionDenied, "account is locked", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.VerifyMFAResponse{
+		Token:                  tokens.AccessToken,
+		RefreshToken:           tokens.RefreshToken,
+		IdToken:                tokens.IDToken,
+		RecoveryCodesRemaining: int32(remaining),
+	}, nil
+}
+
+func (s *serverAPI) RegenerateRecoveryCodes(
+	ctx context.Context,
+	req *ssov1.RegenerateRecoveryCodesRequest,
+) (*ssov1.RegenerateRecoveryCodesResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	recoveryCodes, err := s.auth.RegenerateRecoveryCodes(ctx, token, req.GetCode())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			return nil, status.Error(codes.InvalidArgument, "invalid code")
+		case errors.Is(err, auth.ErrMFANotEnabled):
+			return nil, status.Error(codes.FailedPrecondition, "mfa is not enabled")
+		case errors.Is(err, auth.ErrTooManyAttempts):
+			return nil, throttledError(codes.ResourceExhausted, "too many attempts", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.RegenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func validateVerifyMFA(req *ssov1.VerifyMFARequest) error {
+	if req.GetMfaChallengeId() == "" {
+		return status.Error(codes.InvalidArgument, "mfa_challenge_id is required")
+	}
+
+	if req.GetCode() == "" {
+		return status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	return nil
+}
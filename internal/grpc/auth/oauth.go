@@ -0,0 +1,271 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/lib/oidc"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OAuth 2.0 error codes (RFC 6749, sections 4.1.2.1 and 5.2, and RFC 8628,
+// section 3.5).
+const (
+	oauthInvalidRequest          = "invalid_request"
+	oauthInvalidClient           = "invalid_client"
+	oauthInvalidGrant            = "invalid_grant"
+	oauthInvalidScope            = "invalid_scope"
+	oauthAccessDenied            = "access_denied"
+	oauthUnsupportedGrantType    = "unsupported_grant_type"
+	oauthUnsupportedResponseType = "unsupported_response_type"
+	oauthAuthorizationPending    = "authorization_pending"
+	oauthSlowDown                = "slow_down"
+	oauthExpiredToken            = "expired_token"
+	oauthServerError             = "server_error"
+	oauthTemporarilyUnavailable  = "temporarily_unavailable"
+
+	oauthErrorDomain = "oauth2"
+)
+
+const tokenTypeBearer = "Bearer"
+
+func (s *serverAPI) Authorize(
+	ctx context.Context,
+	req *ssov1.AuthorizeRequest,
+) (*ssov1.AuthorizeResponse, error) {
+
+	err := validateAuthorize(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.auth.Authorize(ctx, models.AuthorizationRequest{
+		ClientID:            req.GetClientId(),
+		RedirectURI:         req.GetRedirectUri(),
+		Scope:               req.GetScope(),
+		Nonce:               req.GetNonce(),
+		CodeChallenge:       req.GetCodeChallenge(),
+		CodeChallengeMethod: req.GetCodeChallengeMethod(),
+	}, req.GetEmail(), req.GetPassword(), req.GetMfaCode())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidClient):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidClient, "unknown client_id")
+		case errors.Is(err, auth.ErrInvalidRedirectURI):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidRequest, "redirect_uri is not registered")
+		case errors.Is(err, auth.ErrInvalidPKCE):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidRequest, "code_challenge with S256 method is required")
+		case errors.Is(err, auth.ErrInvalidScope):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidScope, "unsupported scope")
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			return nil, oauthError(codes.Unauthenticated, oauthAccessDenied, "invalid credentials")
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			return nil, oauthError(codes.FailedPrecondition, oauthAccessDenied, "email is not verified")
+		case errors.Is(err, auth.ErrMFARequired):
+			return nil, oauthError(codes.Unauthenticated, oauthAccessDenied, "mfa_code is required")
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			return nil, oauthError(codes.Unauthenticated, oauthAccessDenied, "invalid mfa_code")
+		case errors.Is(err, auth.ErrTooManyAttempts):
+			return nil, oauthError(codes.ResourceExhausted, oauthAccessDenied, "too many login attempts")
+		case errors.Is(err, auth.ErrAccountLocked):
+			return nil, oauthError(codes.PermissionDenied, oauthAccessDenied, "account is locked")
+		}
+		return nil, oauthError(codes.Internal, oauthServerError, "internal error")
+	}
+
+	return &ssov1.AuthorizeResponse{
+		Code:  code,
+		State: req.GetState(),
+	}, nil
+}
+
+func (s *serverAPI) Token(
+	ctx context.Context,
+	req *ssov1.TokenRequest,
+) (*ssov1.TokenResponse, error) {
+
+	var (
+		tokens models.Tokens
+		err    error
+	)
+
+	switch req.GetGrantType() {
+	case oidc.GrantTypeAuthorizationCode:
+		if err := validateAuthorizationCodeGrant(req); err != nil {
+			return nil, err
+		}
+
+		tokens, err = s.auth.ExchangeAuthorizationCode(
+			ctx,
+			req.GetCode(),
+			req.GetClientId(),
+			req.GetClientSecret(),
+			req.GetRedirectUri(),
+			req.GetCodeVerifier(),
+		)
+	case oidc.GrantTypeRefreshToken:
+		if err := validateRefreshTokenGrant(req); err != nil {
+			return nil, err
+		}
+
+		tokens, err = s.auth.ExchangeRefreshToken(ctx, req.GetRefreshToken(), req.GetClientId(), req.GetClientSecret())
+	case oidc.GrantTypeClientCredentials:
+		if err := validateClientCredentialsGrant(req); err != nil {
+			return nil, err
+		}
+
+		tokens, err = s.auth.ClientCredentials(ctx, req.GetClientId(), req.GetClientSecret(), req.GetScope())
+	case oidc.GrantTypeDeviceCode:
+		if err := validateDeviceCodeGrant(req); err != nil {
+			return nil, err
+		}
+
+		tokens, err = s.auth.ExchangeDeviceCode(ctx, req.GetDeviceCode(), req.GetClientId(), req.GetClientSecret())
+	case "":
+		return nil, oauthError(codes.InvalidArgument, oauthInvalidRequest, "grant_type is required")
+	default:
+		return nil, oauthError(codes.InvalidArgument, oauthUnsupportedGrantType, "unsupported grant_type")
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidClient):
+			return nil, oauthError(codes.Unauthenticated, oauthInvalidClient, "client authentication failed")
+		case errors.Is(err, auth.ErrInvalidGrant), errors.Is(err, auth.ErrInvalidToken):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidGrant, "invalid or expired grant")
+		case errors.Is(err, auth.ErrInvalidScope):
+			return nil, oauthError(codes.InvalidArgument, oauthInvalidScope, "scope is not allowed for the client")
+		case errors.Is(err, auth.ErrAuthorizationPending):
+			return nil, oauthError(codes.FailedPrecondition, oauthAuthorizationPending, "the user has not decided yet")
+		case errors.Is(err, auth.ErrSlowDown):
+			return nil, oauthError(codes.FailedPrecondition, oauthSlowDown, "polling too frequently")
+		case errors.Is(err, auth.ErrAccessDenied):
+			return nil, oauthError(codes.PermissionDenied, oauthAccessDenied, "the user denied the request")
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			return nil, oauthError(codes.FailedPrecondition, oauthAccessDenied, "email is not verified")
+		case errors.Is(err, auth.ErrExpiredToken):
+			return nil, oauthError(codes.InvalidArgument, oauthExpiredToken, "device_code has expired")
+		}
+		return nil, oauthError(codes.Internal, oauthServerError, "internal error")
+	}
+
+	return &ssov1.TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		IdToken:      tokens.IDToken,
+		Scope:        tokens.Scope,
+	}, nil
+}
+
+func validateAuthorize(req *ssov1.AuthorizeRequest) error {
+	if req.GetClientId() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "client_id is required")
+	}
+
+	if req.GetRedirectUri() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "redirect_uri is required")
+	}
+
+	if req.GetResponseType() != "code" {
+		return oauthError(codes.InvalidArgument, oauthUnsupportedResponseType, "response_type must be code")
+	}
+
+	if req.GetEmail() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "email is required")
+	}
+
+	if req.GetPassword() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "password is required")
+	}
+
+	return nil
+}
+
+func validateAuthorizationCodeGrant(req *ssov1.TokenRequest) error {
+	if req.GetCode() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "code is required")
+	}
+
+	if req.GetClientId() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "client_id is required")
+	}
+
+	if req.GetRedirectUri() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "redirect_uri is required")
+	}
+
+	if req.GetCodeVerifier() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "code_verifier is required")
+	}
+
+	return nil
+}
+
+func validateRefreshTokenGrant(req *ssov1.TokenRequest) error {
+	if req.GetRefreshToken() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "refresh_token is required")
+	}
+
+	if req.GetClientId() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "client_id is required")
+	}
+
+	return nil
+}
+
+func validateDeviceCodeGrant(req *ssov1.TokenRequest) error {
+	if req.GetDeviceCode() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "device_code is required")
+	}
+
+	if req.GetClientId() == "" {
+		return oauthError(codes.InvalidArgument, oauthInvalidRequest, "client_id is required")
+	}
+
+	return nil
+}
+
+func validateClientCredentialsGrant(req *ssov1.TokenRequest) error {
+	if req.GetClientId() == "" || req.GetClientSecret() == "" {
+		return oauthError(codes.Unauthenticated, oauthInvalidClient, "client_id and client_secret are required")
+	}
+
+	return nil
+}
+
+// oauthError builds a status carrying the OAuth 2.0 error code, so that the
+// HTTP endpoints can render it the way RFC 6749 requires.
+func oauthError(code codes.Code, reason string, description string) error {
+	st, err := status.New(code, description).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: oauthErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, description)
+	}
+
+	return st.Err()
+}
+
+// oauthErrorReason returns the OAuth 2.0 error code and description of an
+// error returned by the OAuth RPCs.
+func oauthErrorReason(err error) (string, string) {
+	st := status.Convert(err)
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == oauthErrorDomain {
+			return info.GetReason(), st.Message()
+		}
+	}
+
+	return oauthServerError, st.Message()
+}
@@ -0,0 +1,459 @@
+package authgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"math"
+	"net/http"
+	"net/url"
+	"sso/internal/lib/clientip"
+	"sso/internal/lib/oidc"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// authorizeParams are the parameters of the authorization request carried
+// through the login form.
+var authorizeParams = []string{
+	"response_type",
+	"client_id",
+	"redirect_uri",
+	"scope",
+	"state",
+	"code_challenge",
+	"code_challenge_method",
+	"nonce",
+}
+
+// loginErrors are the messages shown on the login form for the errors of
+// Authorize that let the user try again.
+var loginErrors = map[string]string{
+	"invalid credentials":     "Invalid email or password",
+	"mfa_code is required":    "Enter the code from your authenticator app",
+	"invalid mfa_code":        "The authenticator code is invalid",
+	"too many login attempts": "Too many failed sign-in attempts, try again later",
+	"account is locked":       "The account is locked after too many failed sign-in attempts, try again later",
+	"rate limit exceeded":     "Too many requests, try again later",
+}
+
+var loginForm = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Sign in</title></head>
+<body>
+<form method="post" action="{{.Action}}">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}{{if .Error}}<p>{{.Error}}</p>
+{{end}}<label>Email <input type="email" name="email" required></label>
+<label>Password <input type="password" name="password" required></label>
+<label>Authenticator or recovery code <input type="text" name="mfa_code" autocomplete="one-time-code"></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+var deviceForm = template.Must(template.New("device").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Connect a device</title></head>
+<body>
+{{if .Done}}<p>Done. You may return to your device.</p>
+{{else}}<form method="post" action="{{.Action}}">
+{{if .Client}}<p>{{.Client}} is requesting access to your account.</p>
+{{end}}{{if .Error}}<p>{{.Error}}</p>
+{{end}}<label>Code <input type="text" name="user_code" value="{{.UserCode}}" required></label>
+<label>Email <input type="email" name="email" required></label>
+<label>Password <input type="password" name="password" required></label>
+<label>Authenticator or recovery code <input type="text" name="mfa_code" autocomplete="one-time-code"></label>
+<button type="submit" name="action" value="approve">Allow</button>
+<button type="submit" name="action" value="deny">Deny</button>
+</form>
+{{end}}</body>
+</html>
+`))
+
+func registerOAuthHandlers(router *runtime.ServeMux, s *serverAPI) error {
+	handlers := []struct {
+		method  string
+		path    string
+		handler runtime.HandlerFunc
+	}{
+		{http.MethodGet, oidc.AuthorizationPath, s.handleAuthorizeForm},
+		{http.MethodPost, oidc.AuthorizationPath, s.handleAuthorize},
+		{http.MethodPost, oidc.TokenPath, s.handleToken},
+		{http.MethodPost, oidc.DeviceAuthorizationPath, s.handleDeviceAuthorization},
+		{http.MethodGet, oidc.DeviceVerificationPath, s.handleDeviceForm},
+		{http.MethodPost, oidc.DeviceVerificationPath, s.handleDeviceVerification},
+	}
+
+	for _, h := range handlers {
+		if err := router.HandlePath(h.method, h.path, h.handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleAuthorizeForm renders the login form of the authorization endpoint.
+func (s *serverAPI) handleAuthorizeForm(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	if _, err := s.auth.AuthorizeClient(r.Context(), query.Get("client_id"), query.Get("redirect_uri")); err != nil {
+		http.Error(w, "invalid client_id or redirect_uri", http.StatusBadRequest)
+
+		return
+	}
+
+	renderLoginForm(w, query, "")
+}
+
+// handleAuthorize authenticates the user and redirects back to the client
+// with an authorization code or an error.
+func (s *serverAPI) handleAuthorize(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "malformed request", http.StatusBadRequest)
+
+		return
+	}
+
+	form := r.PostForm
+	redirectURI := form.Get("redirect_uri")
+
+	if _, err := s.auth.AuthorizeClient(r.Context(), form.Get("client_id"), redirectURI); err != nil {
+		http.Error(w, "invalid client_id or redirect_uri", http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := s.gateway.Authorize(gatewayContext(r), &ssov1.AuthorizeRequest{
+		ResponseType:        form.Get("response_type"),
+		ClientId:            form.Get("client_id"),
+		RedirectUri:         redirectURI,
+		Scope:               form.Get("scope"),
+		State:               form.Get("state"),
+		CodeChallenge:       form.Get("code_challenge"),
+		CodeChallengeMethod: form.Get("code_challenge_method"),
+		Nonce:               form.Get("nonce"),
+		Email:               form.Get("email"),
+		Password:            form.Get("password"),
+		MfaCode:             form.Get("mfa_code"),
+	})
+
+	params := url.Values{}
+
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.ResourceExhausted, codes.PermissionDenied:
+			message, ok := loginErrors[status.Convert(err).Message()]
+			if !ok {
+				message = loginErrors["invalid credentials"]
+			}
+
+			renderLoginForm(w, form, message)
+
+			return
+		}
+
+		reason, description := oauthErrorReason(err)
+
+		params.Set("error", reason)
+		params.Set("error_description", description)
+	} else {
+		params.Set("code", resp.GetCode())
+	}
+
+	if state := form.Get("state"); state != "" {
+		params.Set("state", state)
+	}
+
+	http.Redirect(w, r, withQuery(redirectURI, params), http.StatusFound)
+}
+
+// handleToken is the form encoded OAuth 2.0 token endpoint.
+func (s *serverAPI) handleToken(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, oauthInvalidRequest, "malformed request")
+
+		return
+	}
+
+	form := r.PostForm
+	clientID, clientSecret := clientCredentials(r)
+
+	resp, err := s.gateway.Token(gatewayContext(r), &ssov1.TokenRequest{
+		GrantType:    form.Get("grant_type"),
+		Code:         form.Get("code"),
+		RedirectUri:  form.Get("redirect_uri"),
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+		CodeVerifier: form.Get("code_verifier"),
+		RefreshToken: form.Get("refresh_token"),
+		Scope:        form.Get("scope"),
+		DeviceCode:   form.Get("device_code"),
+	})
+
+	writeOAuthResponse(w, resp, err)
+}
+
+// handleDeviceAuthorization is the form encoded device authorization endpoint
+// of RFC 8628.
+func (s *serverAPI) handleDeviceAuthorization(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, oauthInvalidRequest, "malformed request")
+
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	resp, err := s.gateway.DeviceAuthorize(gatewayContext(r), &ssov1.DeviceAuthorizeRequest{
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        r.PostForm.Get("scope"),
+	})
+
+	writeOAuthResponse(w, resp, err)
+}
+
+// handleDeviceForm renders the verification page where the user enters the
+// user code shown by the device.
+func (s *serverAPI) handleDeviceForm(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	userCode := r.URL.Query().Get("user_code")
+
+	var client, errorMessage string
+
+	if userCode != "" {
+		ctx := clientip.WithIP(r.Context(), clientip.FromAddr(r.RemoteAddr))
+
+		if err := s.allowPage(ctx, "VerifyDevice"); err != nil {
+			renderDeviceForm(w, userCode, "", loginErrors["rate limit exceeded"], false)
+
+			return
+		}
+
+		app, err := s.auth.DeviceClient(ctx, userCode)
+		if err != nil {
+			errorMessage = "The code is invalid or has expired"
+		} else {
+			client = app.Name
+		}
+	}
+
+	renderDeviceForm(w, userCode, client, errorMessage, false)
+}
+
+// handleDeviceVerification signs the user in and records their decision on
+// the device authorization.
+func (s *serverAPI) handleDeviceVerification(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "malformed request", http.StatusBadRequest)
+
+		return
+	}
+
+	form := r.PostForm
+	userCode := form.Get("user_code")
+	ctx := clientip.WithIP(r.Context(), clientip.FromAddr(r.RemoteAddr))
+
+	if err := s.allowPage(ctx, "VerifyDevice"); err != nil {
+		renderDeviceForm(w, userCode, "", loginErrors["rate limit exceeded"], false)
+
+		return
+	}
+
+	err := s.auth.VerifyDeviceWithCredentials(
+		ctx,
+		userCode,
+		form.Get("email"),
+		form.Get("password"),
+		form.Get("mfa_code"),
+		form.Get("action") == "approve",
+	)
+
+	switch {
+	case err == nil:
+		renderDeviceForm(w, userCode, "", "", true)
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		renderDeviceForm(w, userCode, "", "Invalid email or password", false)
+	case errors.Is(err, auth.ErrMFARequired):
+		renderDeviceForm(w, userCode, "", loginErrors["mfa_code is required"], false)
+	case errors.Is(err, auth.ErrInvalidMFACode):
+		renderDeviceForm(w, userCode, "", loginErrors["invalid mfa_code"], false)
+	case errors.Is(err, auth.ErrTooManyAttempts):
+		renderDeviceForm(w, userCode, "", loginErrors["too many login attempts"], false)
+	case errors.Is(err, auth.ErrAccountLocked):
+		renderDeviceForm(w, userCode, "", loginErrors["account is locked"], false)
+	case errors.Is(err, auth.ErrInvalidUserCode):
+		renderDeviceForm(w, userCode, "", "The code is invalid or has expired", false)
+	default:
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+// gatewayContext returns the context to call the service through the
+// gateway connection with, carrying the address of the client the way the
+// gateway does for the RPCs it serves.
+func gatewayContext(r *http.Request) context.Context {
+	return metadata.AppendToOutgoingContext(r.Context(), "x-forwarded-for", clientip.FromAddr(r.RemoteAddr))
+}
+
+// allowPage applies the rate limit rule of the RPC method to a page that
+// calls the service directly rather than through the gateway connection.
+func (s *serverAPI) allowPage(ctx context.Context, method string) error {
+	if s.limiter == nil {
+		return nil
+	}
+
+	return s.limiter.Allow(ctx, method)
+}
+
+func renderLoginForm(w http.ResponseWriter, values url.Values, errorMessage string) {
+	params := make(map[string]string, len(authorizeParams))
+	for _, name := range authorizeParams {
+		params[name] = values.Get(name)
+	}
+
+	setPageHeaders(w)
+
+	_ = loginForm.Execute(w, struct {
+		Action string
+		Params map[string]string
+		Error  string
+	}{
+		Action: oidc.AuthorizationPath,
+		Params: params,
+		Error:  errorMessage,
+	})
+}
+
+func renderDeviceForm(w http.ResponseWriter, userCode string, client string, errorMessage string, done bool) {
+	setPageHeaders(w)
+
+	_ = deviceForm.Execute(w, struct {
+		Action   string
+		UserCode string
+		Client   string
+		Error    string
+		Done     bool
+	}{
+		Action:   oidc.DeviceVerificationPath,
+		UserCode: userCode,
+		Client:   client,
+		Error:    errorMessage,
+		Done:     done,
+	})
+}
+
+func setPageHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+}
+
+// clientCredentials returns the client credentials sent with HTTP Basic
+// authentication or, failing that, in the request body.
+func clientCredentials(r *http.Request) (string, string) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		clientID, _ := url.QueryUnescape(id)
+		clientSecret, _ := url.QueryUnescape(secret)
+
+		return clientID, clientSecret
+	}
+
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// writeOAuthResponse writes the response of an OAuth endpoint as JSON, or the
+// OAuth 2.0 error carried by err.
+func writeOAuthResponse(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		reason, description := oauthErrorReason(err)
+
+		// Calls refused by the rate limiter carry no OAuth 2.0 error.
+		if status.Code(err) == codes.ResourceExhausted && reason == oauthServerError {
+			if delay, ok := retryDelay(err); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			}
+
+			writeOAuthError(w, http.StatusTooManyRequests, oauthTemporarilyUnavailable, description)
+
+			return
+		}
+
+		code := http.StatusBadRequest
+		switch reason {
+		case oauthInvalidClient:
+			code = http.StatusUnauthorized
+		case oauthServerError:
+			code = http.StatusInternalServerError
+		}
+
+		writeOAuthError(w, code, reason, description)
+
+		return
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp)
+	if err != nil {
+		writeOAuthError(w, http.StatusInternalServerError, oauthServerError, "internal error")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// retryDelay returns the delay of the RetryInfo detail of err.
+func retryDelay(err error) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	return 0, false
+}
+
+func writeOAuthError(w http.ResponseWriter, code int, reason string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}{reason, description})
+}
+
+func withQuery(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,57 @@
+package authgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sso/internal/lib/oidc"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetOpenIDConfiguration(
+	ctx context.Context,
+	req *ssov1.GetOpenIDConfigurationRequest,
+) (*httpbody.HttpBody, error) {
+
+	data, err := json.Marshal(oidc.NewDiscovery(s.issuer))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+func (s *serverAPI) UserInfo(
+	ctx context.Context,
+	req *ssov1.UserInfoRequest,
+) (*ssov1.UserInfoResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.auth.UserInfo(ctx, token)
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.UserInfoResponse{
+		Sub:           user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package authgrpc
+
+import (
+	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordPolicyError builds the status of a password that breaks the
+// password policy, with a violation of the field per broken rule.
+func passwordPolicyError(field string, err error) error {
+	const message = "password does not satisfy the policy"
+
+	var policyErr *auth.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return status.Error(codes.InvalidArgument, message)
+	}
+
+	badRequest := &errdetails.BadRequest{}
+
+	for _, violation := range policyErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: field + " " + violation.Description,
+			Reason:      violation.Reason,
+		})
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, message).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+
+	return st.Err()
+}
+
+func passwordPolicyFromProto(policy *ssov1.PasswordPolicy) *models.PasswordPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &models.PasswordPolicy{
+		MinLength:      int(policy.GetMinLength()),
+		MaxLength:      int(policy.GetMaxLength()),
+		MinCharClasses: int(policy.GetMinCharClasses()),
+		DisallowEmail:  policy.GetDisallowEmail(),
+		MinStrength:    int(policy.GetMinStrength()),
+	}
+}
+
+func passwordPolicyToProto(policy *models.PasswordPolicy) *ssov1.PasswordPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &ssov1.PasswordPolicy{
+		MinLength:      int32(policy.MinLength),
+		MaxLength:      int32(policy.MaxLength),
+		MinCharClasses: int32(policy.MinCharClasses),
+		DisallowEmail:  policy.DisallowEmail,
+		MinStrength:    int32(policy.MinStrength),
+	}
+}
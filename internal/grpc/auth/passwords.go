@@ -0,0 +1,124 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) ChangePassword(
+	ctx context.Context,
+	req *ssov1.ChangePasswordRequest,
+) (*ssov1.ChangePasswordResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateChangePassword(req); err != nil {
+		return nil, err
+	}
+
+	err = s.auth.ChangePassword(ctx, token, req.GetCurrentPassword(), req.GetNewPassword())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid current_password")
+		case errors.Is(err, auth.ErrWeakPassword):
+			return nil, passwordPolicyError("new_password", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.ChangePasswordResponse{}, nil
+}
+
+func (s *serverAPI) RequestPasswordReset(
+	ctx context.Context,
+	req *ssov1.RequestPasswordResetRequest,
+) (*ssov1.RequestPasswordResetResponse, error) {
+
+	if err := validateRequestPasswordReset(req); err != nil {
+		return nil, err
+	}
+
+	err := s.auth.RequestPasswordReset(ctx, req.GetEmail(), req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidAppID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *serverAPI) ConfirmPasswordReset(
+	ctx context.Context,
+	req *ssov1.ConfirmPasswordResetRequest,
+) (*ssov1.ConfirmPasswordResetResponse, error) {
+
+	if err := validateConfirmPasswordReset(req); err != nil {
+		return nil, err
+	}
+
+	err := s.auth.ConfirmPasswordReset(ctx, req.GetToken(), req.GetNewPassword())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		if errors.Is(err, auth.ErrWeakPassword) {
+			return nil, passwordPolicyError("new_password", err)
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.ConfirmPasswordResetResponse{}, nil
+}
+
+func validateChangePassword(req *ssov1.ChangePasswordRequest) error {
+	if req.GetCurrentPassword() == "" {
+		return status.Error(codes.InvalidArgument, "current_password is required")
+	}
+
+	if req.GetNewPassword() == "" {
+		return status.Error(codes.InvalidArgument, "new_password is required")
+	}
+
+	return nil
+}
+
+func validateRequestPasswordReset(req *ssov1.RequestPasswordResetRequest) error {
+	if req.GetEmail() == "" {
+		return status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
+
+func validateConfirmPasswordReset(req *ssov1.ConfirmPasswordResetRequest) error {
+	if req.GetToken() == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	if req.GetNewPassword() == "" {
+		return status.Error(codes.InvalidArgument, "new_password is required")
+	}
+
+	return nil
+}
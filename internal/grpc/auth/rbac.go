@@ -0,0 +1,181 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) CreateRole(
+	ctx context.Context,
+	req *ssov1.CreateRoleRequest,
+) (*ssov1.CreateRoleResponse, error) {
+
+	err := validateCreateRole(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	roleID, err := s.auth.CreateRole(ctx, req.GetAppUuid(), req.GetName(), req.GetPermissions())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrRoleExists):
+			return nil, status.Error(codes.AlreadyExists, "role already exists")
+		case errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		case errors.Is(err, auth.ErrInvalidRoleName):
+			return nil, status.Error(codes.InvalidArgument, "invalid name")
+		case errors.Is(err, auth.ErrInvalidPermission):
+			return nil, status.Error(codes.InvalidArgument, "invalid permissions")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.CreateRoleResponse{
+		RoleId: roleID,
+	}, nil
+}
+
+func (s *serverAPI) AddRolePermission(
+	ctx context.Context,
+	req *ssov1.AddRolePermissionRequest,
+) (*ssov1.AddRolePermissionResponse, error) {
+
+	err := validateAddRolePermission(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auth.AddRolePermission(ctx, req.GetRoleId(), req.GetPermission())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrRoleNotFound):
+			return nil, status.Error(codes.NotFound, "role not found")
+		case errors.Is(err, auth.ErrInvalidPermission):
+			return nil, status.Error(codes.InvalidArgument, "invalid permission")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.AddRolePermissionResponse{}, nil
+}
+
+func (s *serverAPI) AssignRole(
+	ctx context.Context,
+	req *ssov1.AssignRoleRequest,
+) (*ssov1.AssignRoleResponse, error) {
+
+	err := validateAssignRole(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auth.AssignRole(ctx, req.GetUserUuid(), req.GetRoleId())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrRoleNotFound):
+			return nil, status.Error(codes.NotFound, "role not found")
+		case errors.Is(err, auth.ErrNotMember):
+			return nil, status.Error(codes.FailedPrecondition, "user is not a member of the app")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.AssignRoleResponse{}, nil
+}
+
+func (s *serverAPI) CheckPermission(
+	ctx context.Context,
+	req *ssov1.CheckPermissionRequest,
+) (*ssov1.CheckPermissionResponse, error) {
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateCheckPermission(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.auth.CheckPermission(ctx, token, req.GetUserUuid(), req.GetAppUuid(), req.GetPermission())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, "not allowed to check permissions of the user in the app")
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.CheckPermissionResponse{
+		Allowed: allowed,
+	}, nil
+}
+
+func validateCreateRole(req *ssov1.CreateRoleRequest) error {
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	if req.GetName() == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	return nil
+}
+
+func validateAddRolePermission(req *ssov1.AddRolePermissionRequest) error {
+	if req.GetRoleId() == "" {
+		return status.Error(codes.InvalidArgument, "role_id is required")
+	}
+
+	if req.GetPermission() == "" {
+		return status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	return nil
+}
+
+func validateAssignRole(req *ssov1.AssignRoleRequest) error {
+	if req.GetUserUuid() == "" {
+		return status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	if req.GetRoleId() == "" {
+		return status.Error(codes.InvalidArgument, "role_id is required")
+	}
+
+	return nil
+}
+
+func validateCheckPermission(req *ssov1.CheckPermissionRequest) error {
+	if req.GetUserUuid() == "" {
+		return status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	if req.GetPermission() == "" {
+		return status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	return nil
+}
@@ -3,9 +3,13 @@ package authgrpc
 import (
 	"context"
 	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/webauthn"
 	"sso/internal/services/auth"
 	"sso/internal/storage"
 	ssov1 "sso/streaming/go/sso"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
@@ -19,7 +23,14 @@ type Auth interface {
 		email string,
 		password string,
 		appID string,
-	) (token string, err error)
+		nonce string,
+	) (tokens models.Tokens, mfaChallengeID string, err error)
+
+	Refresh(
+		ctx context.Context,
+		refreshToken string,
+		appID string,
+	) (tokens models.Tokens, err error)
 
 	RegisterNewUser(
 		ctx context.Context,
@@ -28,21 +39,274 @@ type Auth interface {
 		app_id string,
 	) (userUUID string, err error)
 
+	Logout(
+		ctx context.Context,
+		accessToken string,
+		refreshToken string,
+	) error
+
+	Introspect(
+		ctx context.Context,
+		accessToken string,
+		appID string,
+	) (claims jwt.Claims, err error)
+
+	UserInfo(ctx context.Context, accessToken string) (user models.User, err error)
+
 	IsAdmin(ctx context.Context, userID string) (isAdmin bool, err error)
 
-	RegisterNewApp(ctx context.Context, appID string, appSecret string) (appUUID string, err error)
+	RegisterNewApp(ctx context.Context, app models.App) (appUUID string, err error)
+
+	GetApp(ctx context.Context, appID string) (app models.App, err error)
+
+	ListApps(
+		ctx context.Context,
+		pageSize int,
+		pageToken string,
+	) (apps []models.App, nextPageToken string, err error)
+
+	UpdateApp(ctx context.Context, appID string, update models.AppUpdate) (app models.App, err error)
+
+	DeleteApp(ctx context.Context, appID string) error
+
+	RotateAppSecret(
+		ctx context.Context,
+		appID string,
+		secret string,
+		revokePrevious bool,
+	) (newSecret string, previousExpiresAt time.Time, err error)
+
+	AuthorizeClient(
+		ctx context.Context,
+		clientID string,
+		redirectURI string,
+	) (app models.App, err error)
+
+	Authorize(
+		ctx context.Context,
+		req models.AuthorizationRequest,
+		email string,
+		password string,
+		mfaCode string,
+	) (code string, err error)
+
+	ExchangeAuthorizationCode(
+		ctx context.Context,
+		code string,
+		clientID string,
+		clientSecret string,
+		redirectURI string,
+		codeVerifier string,
+	) (tokens models.Tokens, err error)
+
+	ExchangeRefreshToken(
+		ctx context.Context,
+		refreshToken string,
+		clientID string,
+		clientSecret string,
+	) (tokens models.Tokens, err error)
+
+	ClientCredentials(
+		ctx context.Context,
+		clientID string,
+		clientSecret string,
+		scope string,
+	) (tokens models.Tokens, err error)
+
+	DeviceAuthorize(
+		ctx context.Context,
+		clientID string,
+		clientSecret string,
+		scope string,
+	) (models.DeviceAuthorization, error)
+
+	DeviceClient(ctx context.Context, userCode string) (models.App, error)
+
+	VerifyDevice(
+		ctx context.Context,
+		accessToken string,
+		userCode string,
+		approve bool,
+	) error
+
+	VerifyDeviceWithCredentials(
+		ctx context.Context,
+		userCode string,
+		email string,
+		password string,
+		mfaCode string,
+		approve bool,
+	) error
+
+	ExchangeDeviceCode(
+		ctx context.Context,
+		deviceCode string,
+		clientID string,
+		clientSecret string,
+	) (tokens models.Tokens, err error)
+
+	CreateRole(
+		ctx context.Context,
+		appID string,
+		name string,
+		permissions []string,
+	) (roleID string, err error)
+
+	AddRolePermission(ctx context.Context, roleID string, permission string) error
+
+	AssignRole(ctx context.Context, userID string, roleID string) error
+
+	CheckPermission(
+		ctx context.Context,
+		accessToken string,
+		userID string,
+		appID string,
+		permission string,
+	) (allowed bool, err error)
+
+	GetUser(ctx context.Context, userID string) (user models.User, err error)
+
+	ListUsers(
+		ctx context.Context,
+		filter models.UserFilter,
+		pageToken string,
+	) (users []models.User, nextPageToken string, err error)
+
+	UpdateUser(ctx context.Context, userID string, update models.UserUpdate) (user models.User, err error)
+
+	DeleteUser(ctx context.Context, userID string) error
+	UnlockUser(ctx context.Context, userID string) error
+
+	VerifyEmail(ctx context.Context, token string) error
+
+	RequestEmailVerification(ctx context.Context, email string, appID string) error
+
+	ChangePassword(
+		ctx context.Context,
+		accessToken string,
+		currentPassword string,
+		newPassword string,
+	) error
+
+	RequestPasswordReset(ctx context.Context, email string, appID string) error
+
+	ConfirmPasswordReset(ctx context.Context, token string, newPassword string) error
+
+	EnrollTOTP(
+		ctx context.Context,
+		accessToken string,
+	) (secret string, uri string, recoveryCodes []string, err error)
+
+	ConfirmTOTP(ctx context.Context, accessToken string, code string) error
+
+	VerifyMFA(
+		ctx context.Context,
+		challengeID string,
+		code string,
+	) (tokens models.Tokens, recoveryCodesRemaining int, err error)
+
+	RegenerateRecoveryCodes(ctx context.Context, accessToken string, code string) (recoveryCodes []string, err error)
+
+	BeginWebAuthnRegistration(
+		ctx context.Context,
+		accessToken string,
+	) (sessionID string, options webauthn.CreationOptions, err error)
+
+	FinishWebAuthnRegistration(
+		ctx context.Context,
+		accessToken string,
+		sessionID string,
+		name string,
+		clientDataJSON []byte,
+		attestationObject []byte,
+	) (credentialID string, err error)
+
+	BeginWebAuthnLogin(
+		ctx context.Context,
+		appID string,
+		nonce string,
+	) (sessionID string, options webauthn.RequestOptions, err error)
+
+	FinishWebAuthnLogin(
+		ctx context.Context,
+		sessionID string,
+		credentialID []byte,
+		clientDataJSON []byte,
+		authenticatorData []byte,
+		signature []byte,
+		userHandle []byte,
+	) (tokens models.Tokens, err error)
+
+	StartEmailLogin(ctx context.Context, email string, appID string, nonce string) error
+
+	CompleteEmailLogin(
+		ctx context.Context,
+		email string,
+		appID string,
+		code string,
+		token string,
+	) (tokens models.Tokens, mfaChallengeID string, err error)
+}
+
+type Keys interface {
+	PublicKeys(ctx context.Context) ([]jwt.Key, error)
+	Rotate(ctx context.Context, alg string) ([]jwt.Key, error)
 }
 
 type serverAPI struct {
 	ssov1.UnimplementedAuthServer
-	auth Auth
+	auth   Auth
+	keys   Keys
+	issuer string
+	// gateway calls the service through the interceptors, for the pages of
+	// the gateway.
+	gateway ssov1.AuthClient
+	limiter RateLimiter
+}
+
+// GatewayConn is the connection the gateway calls the service through. The
+// service is registered on it as well, see Register.
+type GatewayConn interface {
+	grpc.ServiceRegistrar
+	grpc.ClientConnInterface
 }
 
-func Register(ctx context.Context, router *runtime.ServeMux, gRPC *grpc.Server, auth Auth) {
-	serveApi := &serverAPI{auth: auth}
+// RateLimiter limits the calls of the pages of the gateway that are not RPCs,
+// like the device verification page, by the rate limit rule of the RPC
+// method. It returns a ResourceExhausted status over the limit.
+type RateLimiter interface {
+	Allow(ctx context.Context, method string) error
+}
+
+// Register registers the service on the gRPC server and on the gateway. The
+// access every RPC requires is declared in Rules. A nil limiter does not
+// limit the pages of the gateway.
+func Register(
+	ctx context.Context,
+	router *runtime.ServeMux,
+	gRPC grpc.ServiceRegistrar,
+	gateway GatewayConn,
+	limiter RateLimiter,
+	auth Auth,
+	keys Keys,
+	issuer string,
+) {
+	serveApi := &serverAPI{
+		auth:    auth,
+		keys:    keys,
+		issuer:  issuer,
+		gateway: ssov1.NewAuthClient(gateway),
+		limiter: limiter,
+	}
 
 	ssov1.RegisterAuthServer(gRPC, serveApi)
-	err := ssov1.RegisterAuthHandlerServer(ctx, router, serveApi)
+	ssov1.RegisterAuthServer(gateway, serveApi)
+	err := ssov1.RegisterAuthHandlerClient(ctx, router, serveApi.gateway)
+	if err != nil {
+		panic(err)
+	}
+
+	err = registerOAuthHandlers(router, serveApi)
 	if err != nil {
 		panic(err)
 	}
@@ -66,17 +330,122 @@ func (s *serverAPI) Login(
 		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), req.GetAppUuid())
+	tokens, mfaChallengeID, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), req.GetAppUuid(), req.GetNonce())
 
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidCredentials) {
 			return nil, status.Error(codes.InvalidArgument, "invalid argument")
 		}
+		if errors.Is(err, auth.ErrInvalidAppID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		}
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			return nil, status.Error(codes.FailedPrecondition, "email is not verified")
+		}
+		if errors.Is(err, auth.ErrTooManyAttempts) {
+			return nil, throttledError(codes.ResourceExhausted, "too many login attempts", err)
+		}
+		if errors.Is(err, auth.ErrAccountLocked) {
+			return nil, throttledError(codes.PermissionDenied, "account is locked", err)
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
+	if mfaChallengeID != "" {
+		return &ssov1.LoginResponse{MfaChallengeId: mfaChallengeID}, nil
+	}
+
 	return &ssov1.LoginResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		IdToken:      tokens.IDToken,
+	}, nil
+}
+
+func (s *serverAPI) Refresh(
+	ctx context.Context,
+	req *ssov1.RefreshRequest,
+) (*ssov1.RefreshResponse, error) {
+
+	err := validateRefresh(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.auth.Refresh(ctx, req.GetRefreshToken(), req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			return nil, status.Error(codes.FailedPrecondition, "email is not verified")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.RefreshResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		IdToken:      tokens.IDToken,
+	}, nil
+}
+
+func (s *serverAPI) Logout(
+	ctx context.Context,
+	req *ssov1.LogoutRequest,
+) (*ssov1.LogoutResponse, error) {
+
+	err := validateLogout(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auth.Logout(ctx, req.GetToken(), req.GetRefreshToken())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.LogoutResponse{}, nil
+}
+
+func (s *serverAPI) Introspect(
+	ctx context.Context,
+	req *ssov1.IntrospectRequest,
+) (*ssov1.IntrospectResponse, error) {
+
+	err := validateIntrospect(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.auth.Introspect(ctx, req.GetToken(), req.GetAppUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return &ssov1.IntrospectResponse{Active: false}, nil
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.IntrospectResponse{
+		Active:      true,
+		Uid:         claims.UID,
+		Email:       claims.Email,
+		AppId:       claims.AppID,
+		Roles:       claims.Roles,
+		Exp:         claims.ExpiresAt.Unix(),
+		Jti:         claims.JTI,
+		ClientId:    claims.ClientID,
+		Scope:       claims.Scope,
+		Permissions: claims.Permissions,
 	}, nil
 }
 
@@ -96,6 +465,21 @@ func (s *serverAPI) Register(
 		if errors.Is(err, auth.ErrUserExists) {
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
 		}
+		if errors.Is(err, auth.ErrInvalidAppID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		}
+		if errors.Is(err, auth.ErrInvalidEmail) {
+			return nil, status.Error(codes.InvalidArgument, "invalid email")
+		}
+		if errors.Is(err, auth.ErrWeakPassword) {
+			return nil, passwordPolicyError("password", err)
+		}
+		if errors.Is(err, auth.ErrTooManyAttempts) {
+			return nil, throttledError(codes.ResourceExhausted, "too many login attempts", err)
+		}
+		if errors.Is(err, auth.ErrAccountLocked) {
+			return nil, throttledError(codes.PermissionDenied, "account is locked", err)
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
@@ -138,11 +522,40 @@ func (s *serverAPI) RegisterApp(
 		return nil, err
 	}
 
-	appID, err := s.auth.RegisterNewApp(ctx, req.GetName(), req.GetSecret())
+	appID, err := s.auth.RegisterNewApp(ctx, models.App{
+		Name:                     req.GetName(),
+		Secret:                   req.GetSecret(),
+		SigningAlgorithm:         req.GetSigningAlgorithm(),
+		RedirectURIs:             req.GetRedirectUris(),
+		AllowedScopes:            req.GetAllowedScopes(),
+		RequireEmailVerification: req.GetRequireEmailVerification(),
+		WebAuthnRPID:             req.GetWebauthnRpId(),
+		WebAuthnOrigins:          req.GetWebauthnOrigins(),
+		PasswordPolicy:           passwordPolicyFromProto(req.GetPasswordPolicy()),
+		PublicClient:             req.GetPublicClient(),
+	})
 	if err != nil {
 		if errors.Is(err, auth.ErrAppExists) {
 			return nil, status.Error(codes.AlreadyExists, "app already exists")
 		}
+		if errors.Is(err, auth.ErrInvalidAlgorithm) {
+			return nil, status.Error(codes.InvalidArgument, "unsupported signing_algorithm")
+		}
+		if errors.Is(err, auth.ErrInvalidRedirectURI) {
+			return nil, status.Error(codes.InvalidArgument, "invalid redirect_uris")
+		}
+		if errors.Is(err, auth.ErrInvalidScope) {
+			return nil, status.Error(codes.InvalidArgument, "invalid allowed_scopes")
+		}
+		if errors.Is(err, auth.ErrInvalidWebAuthnRPID) {
+			return nil, status.Error(codes.InvalidArgument, "invalid webauthn_rp_id")
+		}
+		if errors.Is(err, auth.ErrInvalidWebAuthnOrigin) {
+			return nil, status.Error(codes.InvalidArgument, "invalid webauthn_origins")
+		}
+		if errors.Is(err, auth.ErrInvalidPasswordPolicy) {
+			return nil, status.Error(codes.InvalidArgument, "invalid password_policy")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	return &ssov1.RegisterAppResponse{
@@ -166,6 +579,38 @@ func validateLogin(req *ssov1.LoginRequest) error {
 	return nil
 }
 
+func validateRefresh(req *ssov1.RefreshRequest) error {
+	if req.GetRefreshToken() == "" {
+		return status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
+
+func validateLogout(req *ssov1.LogoutRequest) error {
+	if req.GetToken() == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	return nil
+}
+
+func validateIntrospect(req *ssov1.IntrospectRequest) error {
+	if req.GetToken() == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	if req.GetAppUuid() == "" {
+		return status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	return nil
+}
+
 func validateRegister(req *ssov1.RegisterRequest) error {
 	if req.GetEmail() == "" {
 		return status.Error(codes.InvalidArgument, "email is required")
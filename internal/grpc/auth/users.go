@@ -0,0 +1,159 @@
+package authgrpc
+
+import (
+	"context"
+	"errors"
+	"sso/internal/domain/models"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) GetUser(
+	ctx context.Context,
+	req *ssov1.GetUserRequest,
+) (*ssov1.GetUserResponse, error) {
+
+	if req.GetUserUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	user, err := s.auth.GetUser(ctx, req.GetUserUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.GetUserResponse{
+		User: userToProto(user),
+	}, nil
+}
+
+func (s *serverAPI) ListUsers(
+	ctx context.Context,
+	req *ssov1.ListUsersRequest,
+) (*ssov1.ListUsersResponse, error) {
+
+	err := validateListUsers(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.UserFilter{
+		AppID:       req.GetAppUuid(),
+		EmailPrefix: req.GetEmailPrefix(),
+		Limit:       int(req.GetPageSize()),
+	}
+
+	if req.GetCreatedAfter() != 0 {
+		filter.CreatedAfter = time.Unix(req.GetCreatedAfter(), 0)
+	}
+
+	if req.GetCreatedBefore() != 0 {
+		filter.CreatedBefore = time.Unix(req.GetCreatedBefore(), 0)
+	}
+
+	users, nextPageToken, err := s.auth.ListUsers(ctx, filter, req.GetPageToken())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	resp := &ssov1.ListUsersResponse{
+		Users:         make([]*ssov1.User, 0, len(users)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, user := range users {
+		resp.Users = append(resp.Users, userToProto(user))
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) UpdateUser(
+	ctx context.Context,
+	req *ssov1.UpdateUserRequest,
+) (*ssov1.UpdateUserResponse, error) {
+
+	if req.GetUserUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	user, err := s.auth.UpdateUser(ctx, req.GetUserUuid(), models.UserUpdate{
+		Email:   req.Email,
+		IsAdmin: req.IsAdmin,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, auth.ErrUserExists):
+			return nil, status.Error(codes.AlreadyExists, "email is already taken")
+		case errors.Is(err, auth.ErrInvalidEmail):
+			return nil, status.Error(codes.InvalidArgument, "invalid email")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.UpdateUserResponse{
+		User: userToProto(user),
+	}, nil
+}
+
+func (s *serverAPI) DeleteUser(
+	ctx context.Context,
+	req *ssov1.DeleteUserRequest,
+) (*ssov1.DeleteUserResponse, error) {
+
+	if req.GetUserUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	err := s.auth.DeleteUser(ctx, req.GetUserUuid())
+
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.DeleteUserResponse{}, nil
+}
+
+func validateListUsers(req *ssov1.ListUsersRequest) error {
+	if req.GetPageSize() < 0 {
+		return status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	if req.GetCreatedAfter() != 0 && req.GetCreatedBefore() != 0 && req.GetCreatedAfter() >= req.GetCreatedBefore() {
+		return status.Error(codes.InvalidArgument, "created_after must be before created_before")
+	}
+
+	return nil
+}
+
+func userToProto(user models.User) *ssov1.User {
+	return &ssov1.User{
+		UserUuid:      user.ID,
+		Email:         user.Email,
+		IsAdmin:       user.IsAdmin,
+		AppUuids:      user.AppIDs,
+		CreatedAt:     user.CreatedAt.Unix(),
+		UpdatedAt:     user.UpdatedAt.Unix(),
+		EmailVerified: user.EmailVerified,
+		MfaEnabled:    user.TOTPEnabled,
+	}
+}
@@ -0,0 +1,203 @@
+package authgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sso/internal/services/auth"
+	ssov1 "sso/streaming/go/sso"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) BeginWebAuthnRegistration(
+	ctx context.Context,
+	req *ssov1.BeginWebAuthnRegistrationRequest,
+) (*ssov1.BeginWebAuthnRegistrationResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, options, err := s.auth.BeginWebAuthnRegistration(ctx, token)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		case errors.Is(err, auth.ErrWebAuthnNotConfigured):
+			return nil, status.Error(codes.FailedPrecondition, "webauthn is not configured for the app")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	optionsJSON, err := json.Marshal(options)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.BeginWebAuthnRegistrationResponse{
+		SessionId:   sessionID,
+		OptionsJson: string(optionsJSON),
+	}, nil
+}
+
+func (s *serverAPI) FinishWebAuthnRegistration(
+	ctx context.Context,
+	req *ssov1.FinishWebAuthnRegistrationRequest,
+) (*ssov1.FinishWebAuthnRegistrationResponse, error) {
+
+	token, err := BearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFinishWebAuthnRegistration(req); err != nil {
+		return nil, err
+	}
+
+	credentialID, err := s.auth.FinishWebAuthnRegistration(
+		ctx,
+		token,
+		req.GetSessionId(),
+		req.GetName(),
+		req.GetClientDataJson(),
+		req.GetAttestationObject(),
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired session_id")
+		case errors.Is(err, auth.ErrInvalidWebAuthnResponse):
+			return nil, status.Error(codes.InvalidArgument, "invalid attestation")
+		case errors.Is(err, auth.ErrWebAuthnCredentialExists):
+			return nil, status.Error(codes.AlreadyExists, "credential already registered")
+		case errors.Is(err, auth.ErrWebAuthnNotConfigured):
+			return nil, status.Error(codes.FailedPrecondition, "webauthn is not configured for the app")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.FinishWebAuthnRegistrationResponse{
+		CredentialId: credentialID,
+	}, nil
+}
+
+func (s *serverAPI) BeginWebAuthnLogin(
+	ctx context.Context,
+	req *ssov1.BeginWebAuthnLoginRequest,
+) (*ssov1.BeginWebAuthnLoginResponse, error) {
+
+	if req.GetAppUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_uuid is required")
+	}
+
+	sessionID, options, err := s.auth.BeginWebAuthnLogin(ctx, req.GetAppUuid(), req.GetNonce())
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		case errors.Is(err, auth.ErrWebAuthnNotConfigured):
+			return nil, status.Error(codes.FailedPrecondition, "webauthn is not configured for the app")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	optionsJSON, err := json.Marshal(options)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.BeginWebAuthnLoginResponse{
+		SessionId:   sessionID,
+		OptionsJson: string(optionsJSON),
+	}, nil
+}
+
+func (s *serverAPI) FinishWebAuthnLogin(
+	ctx context.Context,
+	req *ssov1.FinishWebAuthnLoginRequest,
+) (*ssov1.FinishWebAuthnLoginResponse, error) {
+
+	if err := validateFinishWebAuthnLogin(req); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.auth.FinishWebAuthnLogin(
+		ctx,
+		req.GetSessionId(),
+		req.GetCredentialId(),
+		req.GetClientDataJson(),
+		req.GetAuthenticatorData(),
+		req.GetSignature(),
+		req.GetUserHandle(),
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidToken):
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired session_id")
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			return nil, status.Error(codes.InvalidArgument, "invalid credentials")
+		case errors.Is(err, auth.ErrInvalidAppID):
+			return nil, status.Error(codes.InvalidArgument, "invalid app_uuid")
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			return nil, status.Error(codes.FailedPrecondition, "email is not verified")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &ssov1.FinishWebAuthnLoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		IdToken:      tokens.IDToken,
+	}, nil
+}
+
+func validateFinishWebAuthnRegistration(req *ssov1.FinishWebAuthnRegistrationRequest) error {
+	if req.GetSessionId() == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	if len(req.GetClientDataJson()) == 0 {
+		return status.Error(codes.InvalidArgument, "client_data_json is required")
+	}
+
+	if len(req.GetAttestationObject()) == 0 {
+		return status.Error(codes.InvalidArgument, "attestation_object is required")
+	}
+
+	return nil
+}
+
+func validateFinishWebAuthnLogin(req *ssov1.FinishWebAuthnLoginRequest) error {
+	if req.GetSessionId() == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	if len(req.GetCredentialId()) == 0 {
+		return status.Error(codes.InvalidArgument, "credential_id is required")
+	}
+
+	if len(req.GetClientDataJson()) == 0 {
+		return status.Error(codes.InvalidArgument, "client_data_json is required")
+	}
+
+	if len(req.GetAuthenticatorData()) == 0 {
+		return status.Error(codes.InvalidArgument, "authenticator_data is required")
+	}
+
+	if len(req.GetSignature()) == 0 {
+		return status.Error(codes.InvalidArgument, "signature is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+// Package cbor implements the subset of CBOR (RFC 8949) used by WebAuthn:
+// integers, byte and text strings, arrays, maps and the simple values false,
+// true and null, all of definite length.
+//
+// Decoded values are int64, []byte, string, []any, map[any]any, bool and
+// nil. Map keys must be integers or text strings.
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var (
+	ErrMalformed   = errors.New("cbor: malformed data")
+	ErrUnsupported = errors.New("cbor: unsupported type")
+)
+
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorSimple   = 7
+
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+
+	// maxDepth bounds the nesting of arrays and maps, so that hostile input
+	// can not exhaust the stack.
+	maxDepth = 16
+)
+
+// Unmarshal decodes a single data item that spans the whole input.
+func Unmarshal(data []byte) (any, error) {
+	v, rest, err := Decode(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing data", ErrMalformed)
+	}
+
+	return v, nil
+}
+
+// Decode decodes the data item at the start of data and returns the bytes
+// that follow it.
+func Decode(data []byte) (any, []byte, error) {
+	return decode(data, 0)
+}
+
+func decode(data []byte, depth int) (any, []byte, error) {
+	if depth > maxDepth {
+		return nil, nil, fmt.Errorf("%w: nested too deep", ErrMalformed)
+	}
+
+	major, arg, data, err := header(data)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflows int64", ErrUnsupported)
+		}
+
+		return int64(arg), data, nil
+	case majorNegative:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflows int64", ErrUnsupported)
+		}
+
+		return -1 - int64(arg), data, nil
+	case majorBytes, majorText:
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: string exceeds data", ErrMalformed)
+		}
+
+		if major == majorText {
+			return string(data[:arg]), data[arg:], nil
+		}
+
+		return bytes.Clone(data[:arg]), data[arg:], nil
+	case majorArray:
+		// Every item takes at least one byte.
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: array exceeds data", ErrMalformed)
+		}
+
+		items := make([]any, 0, arg)
+
+		for range arg {
+			var item any
+
+			item, data, err = decode(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, data, nil
+	case majorMap:
+		if arg > uint64(len(data))/2 {
+			return nil, nil, fmt.Errorf("%w: map exceeds data", ErrMalformed)
+		}
+
+		m := make(map[any]any, arg)
+
+		for range arg {
+			var key, value any
+
+			key, data, err = decode(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("%w: map key of type %T", ErrUnsupported, key)
+			}
+
+			if _, ok := m[key]; ok {
+				return nil, nil, fmt.Errorf("%w: duplicate map key", ErrMalformed)
+			}
+
+			value, data, err = decode(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			m[key] = value
+		}
+
+		return m, data, nil
+	case majorSimple:
+		switch arg {
+		case simpleFalse:
+			return false, data, nil
+		case simpleTrue:
+			return true, data, nil
+		case simpleNull:
+			return nil, data, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: major type %d", ErrUnsupported, major)
+}
+
+// header decodes the initial byte and the argument of a data item.
+func header(data []byte) (byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, nil, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	var size int
+
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		// Indefinite lengths are not allowed in WebAuthn.
+		return 0, 0, nil, fmt.Errorf("%w: additional information %d", ErrUnsupported, info)
+	}
+
+	if len(data) < size {
+		return 0, 0, nil, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+
+	var arg uint64
+
+	switch size {
+	case 1:
+		arg = uint64(data[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(data))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(data))
+	case 8:
+		arg = binary.BigEndian.Uint64(data)
+	}
+
+	return major, arg, data[size:], nil
+}
+
+// Marshal encodes v, which is built from the types Unmarshal returns, int
+// and map[int]any. Map keys are sorted in the canonical CTAP2 order.
+func Marshal(v any) ([]byte, error) {
+	return encode(nil, v)
+}
+
+func encode(buf []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case int:
+		return encodeInt(buf, int64(v)), nil
+	case int64:
+		return encodeInt(buf, v), nil
+	case []byte:
+		return append(appendHeader(buf, majorBytes, uint64(len(v))), v...), nil
+	case string:
+		return append(appendHeader(buf, majorText, uint64(len(v))), v...), nil
+	case []any:
+		buf = appendHeader(buf, majorArray, uint64(len(v)))
+
+		for _, item := range v {
+			var err error
+
+			if buf, err = encode(buf, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return buf, nil
+	case map[int]any:
+		m := make(map[any]any, len(v))
+		for key, value := range v {
+			m[int64(key)] = value
+		}
+
+		return encodeMap(buf, m)
+	case map[any]any:
+		return encodeMap(buf, v)
+	case bool:
+		if v {
+			return append(buf, majorSimple<<5|simpleTrue), nil
+		}
+
+		return append(buf, majorSimple<<5|simpleFalse), nil
+	case nil:
+		return append(buf, majorSimple<<5|simpleNull), nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupported, v)
+}
+
+func encodeInt(buf []byte, v int64) []byte {
+	if v < 0 {
+		return appendHeader(buf, majorNegative, uint64(-1-v))
+	}
+
+	return appendHeader(buf, majorUnsigned, uint64(v))
+}
+
+func encodeMap(buf []byte, m map[any]any) ([]byte, error) {
+	type entry struct {
+		key   []byte
+		value any
+	}
+
+	entries := make([]entry, 0, len(m))
+
+	for key, value := range m {
+		switch key.(type) {
+		case int64, string:
+		default:
+			return nil, fmt.Errorf("%w: map key of type %T", ErrUnsupported, key)
+		}
+
+		encoded, err := encode(nil, key)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry{key: encoded, value: value})
+	}
+
+	// Shorter keys sort first, keys of equal length sort bytewise.
+	slices.SortFunc(entries, func(a, b entry) int {
+		if len(a.key) != len(b.key) {
+			return len(a.key) - len(b.key)
+		}
+
+		return bytes.Compare(a.key, b.key)
+	})
+
+	buf = appendHeader(buf, majorMap, uint64(len(entries)))
+
+	for _, e := range entries {
+		var err error
+
+		buf = append(buf, e.key...)
+
+		if buf, err = encode(buf, e.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+func appendHeader(buf []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(buf, major<<5|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(buf, major<<5|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major<<5|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major<<5|26), uint32(arg))
+	}
+
+	return binary.BigEndian.AppendUint64(append(buf, major<<5|27), arg)
+}
@@ -0,0 +1,163 @@
+package cbor_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"sso/internal/lib/cbor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want any
+	}{
+		{name: "zero", data: "00", want: int64(0)},
+		{name: "one byte argument", data: "1818", want: int64(24)},
+		{name: "eight byte argument", data: "1b000000e8d4a51000", want: int64(1000000000000)},
+		{name: "negative", data: "3863", want: int64(-100)},
+		{name: "bytes", data: "4401020304", want: []byte{1, 2, 3, 4}},
+		{name: "text", data: "6449455446", want: "IETF"},
+		{name: "array", data: "83010203", want: []any{int64(1), int64(2), int64(3)}},
+		{name: "map", data: "a201026161f5", want: map[any]any{int64(1): int64(2), "a": true}},
+		{name: "null", data: "f6", want: nil},
+		{name: "false", data: "f4", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := cbor.Unmarshal(decodeHex(t, tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	v := map[int]any{
+		1:  int64(2),
+		3:  int64(-7),
+		-1: int64(1),
+		-2: []byte{0xde, 0xad},
+		-3: "text",
+		4:  []any{true, false, nil},
+	}
+
+	data, err := cbor.Marshal(v)
+	require.NoError(t, err)
+
+	// Canonical order: 1, 3, 4, -1, -2, -3.
+	assert.Equal(t, "a6"+"0102"+"0326"+"0483f5f4f6"+"2001"+"2142dead"+"226474657874", hex.EncodeToString(data))
+
+	decoded, err := cbor.Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{
+		int64(1):  int64(2),
+		int64(3):  int64(-7),
+		int64(-1): int64(1),
+		int64(-2): []byte{0xde, 0xad},
+		int64(-3): "text",
+		int64(4):  []any{true, false, nil},
+	}, decoded)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		// Truncated input.
+		{name: "empty", data: "", err: cbor.ErrMalformed},
+		{name: "missing one byte argument", data: "18", err: cbor.ErrMalformed},
+		{name: "short two byte argument", data: "1900", err: cbor.ErrMalformed},
+		{name: "short eight byte argument", data: "1b00000000", err: cbor.ErrMalformed},
+		{name: "short bytes", data: "430102", err: cbor.ErrMalformed},
+		{name: "short text", data: "636162", err: cbor.ErrMalformed},
+		{name: "missing array item", data: "8201", err: cbor.ErrMalformed},
+		{name: "missing map value", data: "a101", err: cbor.ErrMalformed},
+		{name: "trailing data", data: "0000", err: cbor.ErrMalformed},
+
+		// Lengths and values that do not fit.
+		{name: "oversized bytes", data: "5affffffff00", err: cbor.ErrMalformed},
+		{name: "oversized text", data: "7b7fffffffffffffff61", err: cbor.ErrMalformed},
+		{name: "oversized array", data: "9bffffffffffffffff00", err: cbor.ErrMalformed},
+		{name: "oversized map", data: "bb7fffffffffffffff0000", err: cbor.ErrMalformed},
+		{name: "unsigned overflow", data: "1b8000000000000000", err: cbor.ErrUnsupported},
+		{name: "negative overflow", data: "3b8000000000000000", err: cbor.ErrUnsupported},
+
+		// Indefinite-length items and the break code.
+		{name: "indefinite bytes", data: "5f4101ff", err: cbor.ErrUnsupported},
+		{name: "indefinite text", data: "7f6161ff", err: cbor.ErrUnsupported},
+		{name: "indefinite array", data: "9f01ff", err: cbor.ErrUnsupported},
+		{name: "indefinite map", data: "bf0102ff", err: cbor.ErrUnsupported},
+		{name: "break", data: "ff", err: cbor.ErrUnsupported},
+		{name: "reserved additional information", data: "1c", err: cbor.ErrUnsupported},
+
+		// Types outside the WebAuthn subset.
+		{name: "tag", data: "c000", err: cbor.ErrUnsupported},
+		{name: "float", data: "f93c00", err: cbor.ErrUnsupported},
+		{name: "undefined", data: "f7", err: cbor.ErrUnsupported},
+		{name: "bytes map key", data: "a14100f6", err: cbor.ErrUnsupported},
+		{name: "duplicate map key", data: "a201000100", err: cbor.ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cbor.Unmarshal(decodeHex(t, tt.data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestUnmarshal_Depth(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		depth  int
+		ok     bool
+	}{
+		{name: "arrays at the limit", prefix: "81", depth: 16, ok: true},
+		{name: "arrays too deep", prefix: "81", depth: 17},
+		{name: "maps at the limit", prefix: "a100", depth: 16, ok: true},
+		{name: "maps too deep", prefix: "a100", depth: 17},
+		{name: "hostile nesting", prefix: "81", depth: 100000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := decodeHex(t, strings.Repeat(tt.prefix, tt.depth)+"00")
+
+			_, err := cbor.Unmarshal(data)
+			if tt.ok {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, cbor.ErrMalformed)
+		})
+	}
+}
+
+func TestMarshal_Unsupported(t *testing.T) {
+	_, err := cbor.Marshal(map[any]any{1.5: int64(1)})
+	assert.ErrorIs(t, err, cbor.ErrUnsupported)
+
+	_, err = cbor.Marshal([]any{uint8(1)})
+	assert.ErrorIs(t, err, cbor.ErrUnsupported)
+}
@@ -0,0 +1,43 @@
+package clientip
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+type ipKey struct{}
+
+// WithIP returns ctx carrying the address of the client the request came
+// from.
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipKey{}, ip)
+}
+
+// IP returns the address set by WithIP, or "" when there is none.
+func IP(ctx context.Context) string {
+	ip, _ := ctx.Value(ipKey{}).(string)
+
+	return ip
+}
+
+// FromAddr returns the host of a "host:port" address, or addr itself when it
+// has no port.
+func FromAddr(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+// FromForwardedFor returns the last address of an X-Forwarded-For header,
+// which is the one added by the proxy in front of the service. The addresses
+// before it are set by the client and can not be trusted.
+func FromForwardedFor(header string) string {
+	if i := strings.LastIndexByte(header, ','); i >= 0 {
+		header = header[i+1:]
+	}
+
+	return strings.TrimSpace(header)
+}
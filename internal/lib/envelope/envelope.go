@@ -0,0 +1,148 @@
+// Package envelope encrypts secrets at rest with envelope encryption: every
+// secret is encrypted with its own random data key, which is stored next to
+// it encrypted with the master key. The master key thus only ever encrypts
+// random keys.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the size of the master key and of the data keys, which are
+// AES-256-GCM keys.
+const KeySize = 32
+
+// version prefixes sealed secrets, so the format can change later.
+const version = "v1"
+
+var (
+	ErrInvalidKey = errors.New("envelope: master key must be 32 bytes")
+	ErrMalformed  = errors.New("envelope: malformed sealed secret")
+)
+
+type Sealer struct {
+	master cipher.AEAD
+}
+
+func New(masterKey []byte) (*Sealer, error) {
+	if len(masterKey) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealer{master: master}, nil
+}
+
+// NewFromBase64 is New with the master key in standard base64 encoding, as
+// it is passed in the config.
+func NewFromBase64(masterKey string) (*Sealer, error) {
+	key, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: decode master key: %w", err)
+	}
+
+	return New(key)
+}
+
+// Seal encrypts plaintext with a new data key.
+func (s *Sealer) Seal(plaintext []byte) (string, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := seal(s.master, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(aead, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		version,
+		base64.RawStdEncoding.EncodeToString(wrappedKey),
+		base64.RawStdEncoding.EncodeToString(ciphertext),
+	}, "."), nil
+}
+
+// Open decrypts a secret sealed by Seal with the same master key.
+func (s *Sealer) Open(sealed string) ([]byte, error) {
+	parts := strings.Split(sealed, ".")
+	if len(parts) != 3 || parts[0] != version {
+		return nil, ErrMalformed
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	dataKey, err := open(s.master, wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(aead, ciphertext)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which prefixes the result.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: open: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,109 @@
+package envelope_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"sso/internal/lib/envelope"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSealer(t *testing.T) *envelope.Sealer {
+	t.Helper()
+
+	key := make([]byte, envelope.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	sealer, err := envelope.New(key)
+	require.NoError(t, err)
+
+	return sealer
+}
+
+func TestSealOpen(t *testing.T) {
+	sealer := newSealer(t)
+
+	for _, plaintext := range []string{"", "secret", strings.Repeat("x", 4096)} {
+		sealed, err := sealer.Seal([]byte(plaintext))
+		require.NoError(t, err)
+
+		opened, err := sealer.Open(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, string(opened))
+	}
+
+	// Every secret gets its own data key and nonces.
+	first, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	second, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestOpen_Tampered(t *testing.T) {
+	sealer := newSealer(t)
+
+	sealed, err := sealer.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	parts := strings.Split(sealed, ".")
+	require.Len(t, parts, 3)
+
+	flip := func(part string) string {
+		data, err := base64.RawStdEncoding.DecodeString(part)
+		require.NoError(t, err)
+
+		data[len(data)-1] ^= 1
+
+		return base64.RawStdEncoding.EncodeToString(data)
+	}
+
+	tests := []struct {
+		name   string
+		sealed string
+	}{
+		{name: "wrapped key", sealed: strings.Join([]string{parts[0], flip(parts[1]), parts[2]}, ".")},
+		{name: "ciphertext", sealed: strings.Join([]string{parts[0], parts[1], flip(parts[2])}, ".")},
+		{name: "swapped parts", sealed: strings.Join([]string{parts[0], parts[2], parts[1]}, ".")},
+		{name: "version", sealed: strings.Join([]string{"v2", parts[1], parts[2]}, ".")},
+		{name: "missing part", sealed: strings.Join(parts[:2], ".")},
+		{name: "not base64", sealed: strings.Join([]string{parts[0], "!!", parts[2]}, ".")},
+		{name: "truncated", sealed: strings.Join([]string{parts[0], parts[1][:4], parts[2]}, ".")},
+		{name: "empty", sealed: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sealer.Open(tt.sealed)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOpen_WrongKey(t *testing.T) {
+	sealed, err := newSealer(t).Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = newSealer(t).Open(sealed)
+	assert.Error(t, err)
+}
+
+func TestNew_InvalidKey(t *testing.T) {
+	_, err := envelope.New(make([]byte, 16))
+	assert.ErrorIs(t, err, envelope.ErrInvalidKey)
+
+	_, err = envelope.NewFromBase64(base64.StdEncoding.EncodeToString(make([]byte, 31)))
+	assert.ErrorIs(t, err, envelope.ErrInvalidKey)
+
+	_, err = envelope.NewFromBase64("not base64")
+	assert.Error(t, err)
+
+	_, err = envelope.NewFromBase64(base64.StdEncoding.EncodeToString(make([]byte, envelope.KeySize)))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,62 @@
+package jwt
+
+import (
+	"fmt"
+	"sso/internal/domain/models"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// emailLoginPurpose marks the tokens of magic links. They carry no app_id
+// claim, so they are never accepted as access tokens either.
+const emailLoginPurpose = "email_login"
+
+// NewEmailLoginToken issues the token of a magic link that completes the
+// email login with the ID for the app. It is signed like the access tokens of
+// the app.
+func NewEmailLoginToken(app models.App, key Key, loginID string, duration time.Duration) (string, error) {
+	claims := jwt.MapClaims{}
+
+	claims["jti"] = loginID
+	claims["aud"] = app.ID
+	claims["purpose"] = emailLoginPurpose
+	claims["exp"] = time.Now().Add(duration).Unix()
+
+	return sign(claims, app, key)
+}
+
+// Audience returns the aud claim without verifying the token, so that the
+// caller can look up the app whose key must be used by ParseEmailLoginToken.
+func Audience(tokenString string) (string, error) {
+	claims := jwt.MapClaims{}
+
+	if _, _, err := new(jwt.Parser).ParseUnverified(tokenString, claims); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	aud, _ := claims["aud"].(string)
+	if aud == "" {
+		return "", fmt.Errorf("%w: aud claim is missing", ErrInvalidToken)
+	}
+
+	return aud, nil
+}
+
+// ParseEmailLoginToken verifies a token issued by NewEmailLoginToken for app
+// and returns the login ID.
+func ParseEmailLoginToken(tokenString string, app models.App, keyFunc KeyFunc) (string, error) {
+	claims, err := verify(tokenString, app, keyFunc)
+	if err != nil {
+		return "", err
+	}
+
+	purpose, _ := claims["purpose"].(string)
+	loginID, _ := claims["jti"].(string)
+
+	if purpose != emailLoginPurpose || !claims.VerifyAudience(app.ID, true) || loginID == "" {
+		return "", ErrInvalidToken
+	}
+
+	return loginID, nil
+}
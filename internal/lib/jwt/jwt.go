@@ -1,26 +1,279 @@
 package jwt
 
 import (
+	"errors"
+	"fmt"
 	"sso/internal/domain/models"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
-func NewToken(user models.User, app models.App, duration time.Duration) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
+var (
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Claims are the claims of an access token issued by NewToken or
+// NewClientToken.
+type Claims struct {
+	UID         string
+	Email       string
+	AppID       string
+	ClientID    string
+	Scope       string
+	JTI         string
+	Roles       []string
+	Permissions []string
+	// TokenVersion is the token version of the user when the token was
+	// issued, see models.User.
+	TokenVersion int
+	ExpiresAt    time.Time
+}
 
-	claims := token.Claims.(jwt.MapClaims)
+// KeyFunc resolves a server key by its id.
+type KeyFunc func(kid string) (Key, error)
 
+// NewToken issues an access token with the id jti for the user with the roles
+// and permissions the user has in the app. Apps using HS256 get tokens signed
+// with the app secret, other apps get tokens signed with key, whose id is put
+// into the kid header.
+func NewToken(
+	jti string,
+	user models.User,
+	app models.App,
+	roles []string,
+	permissions []string,
+	key Key,
+	duration time.Duration,
+) (string, error) {
+	claims := jwt.MapClaims{}
+
+	claims["jti"] = jti
 	claims["uid"] = user.ID
 	claims["email"] = user.Email
+	claims["email_verified"] = user.EmailVerified
 	claims["exp"] = time.Now().Add(duration).Unix()
 	claims["app_id"] = app.ID
+	claims["ver"] = user.TokenVersion
+
+	if len(roles) > 0 {
+		claims["roles"] = roles
+	}
+
+	if len(permissions) > 0 {
+		claims["permissions"] = permissions
+	}
+
+	return sign(claims, app, key)
+}
+
+// NewClientToken issues an access token for the app itself. The token has no
+// user subject; client_id names the app and scope the granted scopes.
+func NewClientToken(app models.App, key Key, scope string, duration time.Duration) (string, error) {
+	claims := jwt.MapClaims{}
 
-	tokenString, err := token.SignedString([]byte(app.Secret))
+	claims["jti"] = uuid.New().String()
+	claims["client_id"] = app.ID
+	claims["exp"] = time.Now().Add(duration).Unix()
+	claims["app_id"] = app.ID
+
+	if scope != "" {
+		claims["scope"] = scope
+	}
+
+	return sign(claims, app, key)
+}
+
+// NewIDToken issues an OpenID Connect ID token for the user with the app as
+// its audience. The nonce is omitted when empty.
+func NewIDToken(
+	user models.User,
+	app models.App,
+	key Key,
+	issuer string,
+	nonce string,
+	duration time.Duration,
+) (string, error) {
+	now := time.Now()
+
+	claims := jwt.MapClaims{}
+
+	claims["iss"] = issuer
+	claims["sub"] = user.ID
+	claims["aud"] = app.ID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(duration).Unix()
+	claims["email"] = user.Email
+	claims["email_verified"] = user.EmailVerified
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	return sign(claims, app, key)
+}
+
+func sign(claims jwt.MapClaims, app models.App, key Key) (string, error) {
+	alg := Algorithm(app)
+
+	method := jwt.GetSigningMethod(alg)
+	if method == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+
+	var signingKey interface{}
+
+	if alg == AlgHS256 {
+		signingKey = []byte(app.Secret)
+	} else {
+		if key.Algorithm != alg || key.Private == nil {
+			return "", fmt.Errorf("%w: %s", ErrKeyMismatch, alg)
+		}
+
+		token.Header["kid"] = key.ID
+		signingKey = key.Private
+	}
+
+	tokenString, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", err
 	}
 
 	return tokenString, nil
 }
+
+// Algorithm returns the signing algorithm of the app. Apps registered before
+// the algorithm became configurable sign with HS256.
+func Algorithm(app models.App) string {
+	if app.SigningAlgorithm == "" {
+		return AlgHS256
+	}
+
+	return app.SigningAlgorithm
+}
+
+// AppID returns the app_id claim without verifying the token, so that the
+// caller can look up the app whose key must be used by Parse.
+func AppID(tokenString string) (string, error) {
+	claims := jwt.MapClaims{}
+
+	if _, _, err := new(jwt.Parser).ParseUnverified(tokenString, claims); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	appID, _ := claims["app_id"].(string)
+	if appID == "" {
+		return "", fmt.Errorf("%w: app_id claim is missing", ErrInvalidToken)
+	}
+
+	return appID, nil
+}
+
+// Parse verifies the signature and expiry of a token issued for app and
+// returns its claims. The token must be signed with the algorithm configured
+// for the app; server keys are looked up with keyFunc.
+func Parse(tokenString string, app models.App, keyFunc KeyFunc) (Claims, error) {
+	mapClaims, err := verify(tokenString, app, keyFunc)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	claims := Claims{}
+	claims.UID, _ = mapClaims["uid"].(string)
+	claims.Email, _ = mapClaims["email"].(string)
+	claims.AppID, _ = mapClaims["app_id"].(string)
+	claims.ClientID, _ = mapClaims["client_id"].(string)
+	claims.Scope, _ = mapClaims["scope"].(string)
+	claims.JTI, _ = mapClaims["jti"].(string)
+
+	claims.Roles = stringsClaim(mapClaims, "roles")
+	claims.Permissions = stringsClaim(mapClaims, "permissions")
+
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+
+	if ver, ok := mapClaims["ver"].(float64); ok {
+		claims.TokenVersion = int(ver)
+	}
+
+	if claims.AppID != app.ID || claims.JTI == "" {
+		return Claims{}, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// verify checks the signature and expiry of a token issued for app and
+// returns its claims.
+func verify(tokenString string, app models.App, keyFunc KeyFunc) (jwt.MapClaims, error) {
+	alg := Algorithm(app)
+
+	secrets := []string{""}
+	if alg == AlgHS256 {
+		// Tokens signed with the previous secret stay valid during the grace
+		// period of a secret rotation.
+		secrets = app.Secrets(time.Now())
+	}
+
+	var token *jwt.Token
+	var err error
+
+	for _, secret := range secrets {
+		token, err = jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+			if t.Method.Alg() != alg {
+				return nil, fmt.Errorf("unexpected signing method %s", t.Header["alg"])
+			}
+
+			if alg == AlgHS256 {
+				return []byte(secret), nil
+			}
+
+			kid, _ := t.Header["kid"].(string)
+
+			key, err := keyFunc(kid)
+			if err != nil {
+				return nil, err
+			}
+
+			if key.Algorithm != alg {
+				return nil, ErrKeyMismatch
+			}
+
+			return key.Public(), nil
+		})
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return mapClaims, nil
+}
+
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	values, ok := claims[name].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value, ok := value.(string); ok {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrKeyMismatch          = errors.New("key does not match signing algorithm")
+)
+
+// Algorithms lists the signing algorithms an app can choose from.
+var Algorithms = []string{AlgHS256, AlgRS256, AlgES256, AlgEdDSA}
+
+// AsymmetricAlgorithms lists the algorithms that sign with a server key.
+var AsymmetricAlgorithms = []string{AlgRS256, AlgES256, AlgEdDSA}
+
+// Key is a server-held private key used to sign tokens.
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+}
+
+func (k Key) Public() crypto.PublicKey {
+	return k.Private.Public()
+}
+
+func IsSupportedAlgorithm(alg string) bool {
+	return slices.Contains(Algorithms, alg)
+}
+
+func IsAsymmetricAlgorithm(alg string) bool {
+	return slices.Contains(AsymmetricAlgorithms, alg)
+}
+
+// GenerateKey creates a new private key for the given asymmetric algorithm.
+func GenerateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case AlgRS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+
+		return private, err
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+}
+
+// ParsePrivateKey decodes a PEM encoded PKCS#8, PKCS#1 or SEC 1 private key
+// and checks that it can be used with alg.
+func ParsePrivateKey(alg string, data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var (
+		key crypto.PrivateKey
+		err error
+	)
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !matchesAlgorithm(alg, key) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyMismatch, alg)
+	}
+
+	return key.(crypto.Signer), nil
+}
+
+// MarshalPrivateKey encodes a private key as a PKCS#8 PEM block.
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func matchesAlgorithm(alg string, key crypto.PrivateKey) bool {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return alg == AlgRS256
+	case *ecdsa.PrivateKey:
+		return alg == AlgES256 && k.Curve == elliptic.P256()
+	case ed25519.PrivateKey:
+		return alg == AlgEdDSA
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/pkce"
+	"strings"
+)
+
+const (
+	JWKSPath          = "/.well-known/jwks.json"
+	UserInfoPath      = "/api/sso/userinfo"
+	AuthorizationPath = "/authorize"
+	TokenPath         = "/token"
+
+	DeviceAuthorizationPath = "/device_authorization"
+	DeviceVerificationPath  = "/device"
+)
+
+const (
+	ScopeOpenID = "openid"
+	ScopeEmail  = "email"
+)
+
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// Scopes lists the scopes clients may request.
+var Scopes = []string{ScopeOpenID, ScopeEmail}
+
+// Discovery is the OpenID Provider metadata document served on
+// /.well-known/openid-configuration.
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+func NewDiscovery(issuer string) Discovery {
+	issuer = strings.TrimRight(issuer, "/")
+
+	return Discovery{
+		Issuer:                      issuer,
+		AuthorizationEndpoint:       issuer + AuthorizationPath,
+		TokenEndpoint:               issuer + TokenPath,
+		DeviceAuthorizationEndpoint: issuer + DeviceAuthorizationPath,
+		JWKSURI:                     issuer + JWKSPath,
+		UserInfoEndpoint:            issuer + UserInfoPath,
+		ResponseTypesSupported:      []string{"code"},
+		GrantTypesSupported: []string{
+			GrantTypeAuthorizationCode,
+			GrantTypeRefreshToken,
+			GrantTypeClientCredentials,
+			GrantTypeDeviceCode,
+		},
+		CodeChallengeMethodsSupported:     []string{pkce.MethodS256},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_post", "client_secret_basic"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  jwt.Algorithms,
+		ScopesSupported:                   Scopes,
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "iat", "exp", "nonce", "email", "email_verified"},
+	}
+}
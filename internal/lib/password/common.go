@@ -0,0 +1,18 @@
+package password
+
+// commonPasswords are the passwords and words most often found in leaked
+// password lists, normalized as by normalize.
+var commonPasswords = []string{
+	"password", "passw", "pass", "qwerty", "qwertz", "azerty", "asdf", "zxcv",
+	"admin", "administrator", "root", "user", "login", "welcome", "letmein",
+	"iloveyou", "love", "monkey", "dragon", "master", "shadow", "sunshine",
+	"princess", "football", "baseball", "soccer", "hockey", "superman",
+	"batman", "trustno", "starwars", "whatever", "freedom", "hello", "secret",
+	"charlie", "michael", "jessica", "ashley", "daniel", "thomas", "jordan",
+	"hunter", "ranger", "buster", "tigger", "pepper", "ginger", "summer",
+	"winter", "spring", "autumn", "flower", "cookie", "cheese", "computer",
+	"internet", "service", "default", "changeme", "access", "test", "guest",
+	"google", "facebook", "mustang", "harley", "matrix", "killer", "pokemon",
+	"banana", "orange", "purple", "yellow", "silver", "golden", "blink",
+	"abc", "qazwsx", "zaq", "mypass", "parol", "privet", "russia", "moscow",
+}
@@ -0,0 +1,144 @@
+package password
+
+import (
+	"fmt"
+	"sso/internal/domain/models"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxBytes is the length of the longest password bcrypt hashes. Longer
+// passwords would be accepted with any suffix.
+const MaxBytes = 72
+
+// MaxStrength is the strength of the least guessable passwords.
+const MaxStrength = 4
+
+// Reasons of violations, in the style of google.rpc.ErrorInfo reasons.
+const (
+	ReasonTooShort      = "TOO_SHORT"
+	ReasonTooLong       = "TOO_LONG"
+	ReasonCharClasses   = "TOO_FEW_CHARACTER_CLASSES"
+	ReasonContainsEmail = "CONTAINS_EMAIL"
+	ReasonTooWeak       = "TOO_WEAK"
+)
+
+// Violation is a rule of a policy a password breaks.
+type Violation struct {
+	Reason      string
+	Description string
+}
+
+// Check returns the rules of the policy the password of the user with the
+// email breaks, or nil when it satisfies the policy.
+func Check(policy models.PasswordPolicy, password string, email string) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+
+	if length < policy.MinLength {
+		violations = append(violations, Violation{
+			Reason:      ReasonTooShort,
+			Description: fmt.Sprintf("must be at least %d characters long", policy.MinLength),
+		})
+	}
+
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		violations = append(violations, Violation{
+			Reason:      ReasonTooLong,
+			Description: fmt.Sprintf("must be at most %d characters long", policy.MaxLength),
+		})
+	} else if len(password) > MaxBytes {
+		violations = append(violations, Violation{
+			Reason:      ReasonTooLong,
+			Description: fmt.Sprintf("must be at most %d bytes long", MaxBytes),
+		})
+	}
+
+	if classes := charClasses(password); classes < policy.MinCharClasses {
+		violations = append(violations, Violation{
+			Reason: ReasonCharClasses,
+			Description: fmt.Sprintf(
+				"must mix at least %d of lowercase letters, uppercase letters, digits and other characters",
+				policy.MinCharClasses,
+			),
+		})
+	}
+
+	if policy.DisallowEmail && containsEmail(password, email) {
+		violations = append(violations, Violation{
+			Reason:      ReasonContainsEmail,
+			Description: "must not contain the email",
+		})
+	}
+
+	if policy.MinStrength > 0 && Strength(password, emailInputs(email)...) < policy.MinStrength {
+		violations = append(violations, Violation{
+			Reason:      ReasonTooWeak,
+			Description: "is too easy to guess, use a longer password with less common words and patterns",
+		})
+	}
+
+	return violations
+}
+
+// charClasses returns how many of lowercase letters, uppercase letters,
+// digits and other characters the password has.
+func charClasses(password string) int {
+	var lower, upper, digit, other int
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+
+	return lower + upper + digit + other
+}
+
+// containsEmail reports whether the password is the email, or contains its
+// local part when it is long enough to be meant as a word.
+func containsEmail(password string, email string) bool {
+	if email == "" {
+		return false
+	}
+
+	password = strings.ToLower(password)
+	email = strings.ToLower(email)
+
+	if strings.Contains(password, email) {
+		return true
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+
+	return utf8.RuneCountInString(local) >= 4 && strings.Contains(password, local)
+}
+
+// emailInputs returns the words of the email an attacker would try first.
+func emailInputs(email string) []string {
+	if email == "" {
+		return nil
+	}
+
+	email = strings.ToLower(email)
+	local, domain, _ := strings.Cut(email, "@")
+
+	inputs := []string{email, local}
+	inputs = append(inputs, strings.FieldsFunc(local, isSeparator)...)
+	inputs = append(inputs, strings.FieldsFunc(domain, isSeparator)...)
+
+	return inputs
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,82 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"sso/internal/domain/models"
+	"sso/internal/lib/password"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func reasons(violations []password.Violation) []string {
+	var reasons []string
+
+	for _, violation := range violations {
+		reasons = append(reasons, violation.Reason)
+	}
+
+	return reasons
+}
+
+func TestCheck(t *testing.T) {
+	policy := models.PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      64,
+		MinCharClasses: 3,
+		DisallowEmail:  true,
+		MinStrength:    2,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		reasons  []string
+	}{
+		{"strong", "x7#Kq9!mZ2", nil},
+		{"too short", "x7#Kq", []string{password.ReasonTooShort}},
+		{"too long", strings.Repeat("x7#Kq9!mZ2", 7), []string{password.ReasonTooLong}},
+		{"one class", "kdjfhgvmqpwz", []string{password.ReasonCharClasses}},
+		{"email", "Jane.Doe@example.com", []string{password.ReasonContainsEmail, password.ReasonTooWeak}},
+		{"local part", "jane.doe#2024X", []string{password.ReasonContainsEmail}},
+		{"common", "P@ssw0rd123!", []string{password.ReasonTooWeak}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reasons, reasons(password.Check(policy, tt.password, "jane.doe@example.com")))
+		})
+	}
+}
+
+func TestCheck_BcryptLimit(t *testing.T) {
+	// Multi-byte characters reach the bcrypt limit before MaxLength.
+	violations := password.Check(models.PasswordPolicy{}, strings.Repeat("пароль", 7), "")
+
+	assert.Equal(t, []string{password.ReasonTooLong}, reasons(violations))
+	assert.Contains(t, violations[0].Description, "72 bytes")
+
+	assert.Empty(t, password.Check(models.PasswordPolicy{}, "a", ""))
+}
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		strength int
+	}{
+		{"password", 0},
+		{"qwerty123", 0},
+		{"aaaaaaaaaaaa", 0},
+		{"abcdefgh1!", 1},
+		{"x7#Kq9!mZ2", password.MaxStrength},
+		{"correcthorsebatterystaple", password.MaxStrength},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.strength, password.Strength(tt.password), tt.password)
+	}
+
+	// User inputs are as easy to guess as common words.
+	assert.Less(t, password.Strength("janedoe1990", "jane", "doe"), password.Strength("janedoe1990"))
+}
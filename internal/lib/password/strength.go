@@ -0,0 +1,200 @@
+package password
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// keyboardRows are the runs of adjacent keys typed as sequences.
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+// leet undoes the common substitutions of letters.
+var leet = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'@': 'a',
+	'$': 's',
+	'!': 'i',
+}
+
+// Strength estimates how hard the password is to guess, from 0 (too
+// guessable) to MaxStrength (very unguessable), in the manner of zxcvbn.
+// Common passwords and the user inputs, such as the email, are counted as a
+// single guess from a short list, and repeated characters and sequences of
+// letters, digits or keys as nearly free. The other characters are worth
+// the bits of the character set the password draws from.
+func Strength(password string, userInputs ...string) int {
+	bits := entropy(password, userInputs)
+
+	switch {
+	case bits < 20:
+		return 0
+	case bits < 30:
+		return 1
+	case bits < 42:
+		return 2
+	case bits < 56:
+		return 3
+	default:
+		return MaxStrength
+	}
+}
+
+func entropy(password string, userInputs []string) float64 {
+	runes := []rune(password)
+	normalized := normalize(runes)
+	covered := make([]bool, len(runes))
+
+	var bits float64
+
+	dictionaries := []struct {
+		words []string
+		bits  float64
+	}{
+		{commonPasswords, math.Log2(float64(len(commonPasswords)))},
+		{userInputs, 1},
+	}
+
+	for _, dictionary := range dictionaries {
+		for _, word := range dictionary.words {
+			word := []rune(strings.ToLower(word))
+			if len(word) < 3 {
+				continue
+			}
+
+			for i := 0; i+len(word) <= len(normalized); i++ {
+				if !matchAt(normalized, covered, word, i) {
+					continue
+				}
+
+				for j := i; j < i+len(word); j++ {
+					covered[j] = true
+				}
+
+				bits += dictionary.bits
+
+				i += len(word) - 1
+			}
+		}
+	}
+
+	charBits := math.Log2(float64(charsetSize(runes)))
+
+	for i := range runes {
+		if covered[i] {
+			continue
+		}
+
+		if i > 0 && !covered[i-1] && predictable(runes, i) {
+			bits++
+
+			continue
+		}
+
+		bits += charBits
+	}
+
+	return bits
+}
+
+// normalize lowercases the password and undoes leet substitutions, so that
+// dictionary words match however they are spelled.
+func normalize(runes []rune) []rune {
+	normalized := make([]rune, len(runes))
+
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+
+		if plain, ok := leet[r]; ok {
+			r = plain
+		}
+
+		normalized[i] = r
+	}
+
+	return normalized
+}
+
+func matchAt(s []rune, covered []bool, word []rune, i int) bool {
+	for j, r := range word {
+		if covered[i+j] || s[i+j] != r {
+			return false
+		}
+	}
+
+	return true
+}
+
+// predictable reports whether the character at i repeats the previous one or
+// continues a sequence of letters, digits or keys started before it.
+func predictable(runes []rune, i int) bool {
+	prev, cur := unicode.ToLower(runes[i-1]), unicode.ToLower(runes[i])
+
+	if prev == cur {
+		return true
+	}
+
+	if delta := cur - prev; delta == 1 || delta == -1 {
+		return true
+	}
+
+	for _, row := range keyboardRows {
+		p, c := strings.IndexRune(row, prev), strings.IndexRune(row, cur)
+
+		if p >= 0 && c >= 0 && (c-p == 1 || p-c == 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// charsetSize is the size of the character set of the classes the password
+// draws from.
+func charsetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{
+		{lower, 26},
+		{upper, 26},
+		{digit, 10},
+		{symbol, 33},
+		{other, 100},
+	} {
+		if class.present {
+			size += class.size
+		}
+	}
+
+	return max(size, 2)
+}
@@ -0,0 +1,46 @@
+package pkce
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// MethodS256 is the only code challenge method accepted by the server, the
+// "plain" method offers no protection against intercepted challenges.
+const MethodS256 = "S256"
+
+const (
+	minVerifierLen = 43
+	maxVerifierLen = 128
+)
+
+// Challenge derives the S256 code challenge of a code verifier (RFC 7636).
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Verify reports whether the verifier is well-formed and matches the S256
+// challenge.
+func Verify(verifier string, challenge string) bool {
+	if len(verifier) < minVerifierLen || len(verifier) > maxVerifierLen {
+		return false
+	}
+
+	for _, c := range verifier {
+		if !isUnreserved(c) {
+			return false
+		}
+	}
+
+	return subtle.ConstantTimeCompare([]byte(Challenge(verifier)), []byte(challenge)) == 1
+}
+
+func isUnreserved(c rune) bool {
+	return c >= 'A' && c <= 'Z' ||
+		c >= 'a' && c <= 'z' ||
+		c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the buckets that are full
+// again.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the memory of the process, so every
+// replica of the service enforces the limits on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	sweptAt time.Time
+}
+
+type memoryBucket struct {
+	Bucket
+	// fullAt is when the bucket is full again, after which it can be
+	// dropped.
+	fullAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+func (s *MemoryStore) TakeToken(_ context.Context, key string, limit Limit, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) >= sweepInterval {
+		for key, bucket := range s.buckets {
+			if !bucket.fullAt.After(now) {
+				delete(s.buckets, key)
+			}
+		}
+
+		s.sweptAt = now
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{}
+		s.buckets[key] = bucket
+	}
+
+	ok, retryAfter := bucket.Take(limit, now)
+	bucket.fullAt = now.Add(time.Duration((float64(limit.Burst) - bucket.Tokens) / limit.Rate * float64(time.Second)))
+
+	return ok, retryAfter, nil
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit allows Rate requests per second on average, in bursts of up to Burst
+// requests.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Every returns the limit of requests per period.
+func Every(requests int, period time.Duration, burst int) Limit {
+	if burst <= 0 {
+		burst = requests
+	}
+
+	return Limit{
+		Rate:  float64(requests) / period.Seconds(),
+		Burst: burst,
+	}
+}
+
+// FillTime is how long an empty bucket takes to fill up.
+func (l Limit) FillTime() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Store keeps the token buckets of the keys, e.g. in memory or in a database
+// shared by the replicas of the service.
+type Store interface {
+	// TakeToken takes a token from the bucket of the key. When the bucket is
+	// empty it returns false and how long until a token is available.
+	TakeToken(ctx context.Context, key string, limit Limit, now time.Time) (ok bool, retryAfter time.Duration, err error)
+}
+
+// StoreFunc adapts a function to a Store.
+type StoreFunc func(ctx context.Context, key string, limit Limit, now time.Time) (bool, time.Duration, error)
+
+func (f StoreFunc) TakeToken(ctx context.Context, key string, limit Limit, now time.Time) (bool, time.Duration, error) {
+	return f(ctx, key, limit, now)
+}
+
+// Bucket is a token bucket. The zero Bucket is full.
+type Bucket struct {
+	Tokens     float64
+	RefilledAt time.Time
+}
+
+// Take refills the bucket for the time passed since it was last refilled and
+// takes a token from it. When the bucket is empty it returns false and how
+// long until a token is available.
+func (b *Bucket) Take(limit Limit, now time.Time) (bool, time.Duration) {
+	if b.RefilledAt.IsZero() {
+		b.Tokens = float64(limit.Burst)
+	} else if elapsed := now.Sub(b.RefilledAt); elapsed > 0 {
+		b.Tokens = math.Min(float64(limit.Burst), b.Tokens+elapsed.Seconds()*limit.Rate)
+	}
+
+	b.RefilledAt = now
+
+	if b.Tokens < 1 {
+		return false, time.Duration(math.Ceil((1 - b.Tokens) / limit.Rate * float64(time.Second)))
+	}
+
+	b.Tokens--
+
+	return true, 0
+}
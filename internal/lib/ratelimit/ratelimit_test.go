@@ -0,0 +1,84 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sso/internal/lib/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	limit := ratelimit.Every(60, time.Minute, 3)
+	now := time.Now()
+
+	var bucket ratelimit.Bucket
+
+	for range 3 {
+		ok, _ := bucket.Take(limit, now)
+		require.True(t, ok)
+	}
+
+	ok, retryAfter := bucket.Take(limit, now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Half a token is refilled, the rest is still missing.
+	ok, retryAfter = bucket.Take(limit, now.Add(500*time.Millisecond))
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	ok, _ = bucket.Take(limit, now.Add(time.Second))
+	assert.True(t, ok)
+
+	// The bucket does not fill up beyond the burst.
+	later := now.Add(time.Hour)
+
+	for range 3 {
+		ok, _ := bucket.Take(limit, later)
+		require.True(t, ok)
+	}
+
+	ok, _ = bucket.Take(limit, later)
+	assert.False(t, ok)
+}
+
+func TestEvery(t *testing.T) {
+	limit := ratelimit.Every(10, time.Minute, 0)
+
+	assert.Equal(t, 10, limit.Burst)
+	assert.Equal(t, time.Minute, limit.FillTime())
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	limit := ratelimit.Every(1, time.Minute, 1)
+	now := time.Now()
+
+	ok, _, err := store.TakeToken(ctx, "a", limit, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, retryAfter, err := store.TakeToken(ctx, "a", limit, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+
+	// Keys have buckets of their own.
+	ok, _, err = store.TakeToken(ctx, "b", limit, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Buckets dropped once full behave like new ones.
+	ok, _, err = store.TakeToken(ctx, "a", limit, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, _, err = store.TakeToken(ctx, "a", limit, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,175 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"sso/internal/lib/cbor"
+)
+
+// COSE algorithm identifiers of the supported credential keys.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// Algorithms are the supported algorithms in the order of preference
+// offered to authenticators.
+var Algorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key parameters (RFC 9052, RFC 9053).
+const (
+	coseKty = 1
+	coseAlg = 3
+	coseCrv = -1
+	coseX   = -2
+	coseY   = -3
+	coseN   = -1
+	coseE   = -2
+
+	ktyOKP = 1
+	ktyEC2 = 2
+	ktyRSA = 3
+
+	crvP256    = 1
+	crvEd25519 = 6
+
+	minRSABits = 2048
+)
+
+var ErrUnsupportedKey = errors.New("webauthn: unsupported credential public key")
+
+// PublicKey verifies the signatures of a credential.
+type PublicKey interface {
+	Verify(data []byte, signature []byte) error
+}
+
+// ParsePublicKey parses a COSE_Key of one of the supported algorithms.
+func ParsePublicKey(coseKey []byte) (PublicKey, error) {
+	v, err := cbor.Unmarshal(coseKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := v.(map[any]any)
+
+	if !ok {
+		return nil, fmt.Errorf("%w: not a map", ErrUnsupportedKey)
+	}
+
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+
+	switch {
+	case kty == ktyEC2 && alg == AlgES256:
+		return parseES256(m)
+	case kty == ktyOKP && alg == AlgEdDSA:
+		return parseEdDSA(m)
+	case kty == ktyRSA && alg == AlgRS256:
+		return parseRS256(m)
+	}
+
+	return nil, fmt.Errorf("%w: kty %d, alg %d", ErrUnsupportedKey, kty, alg)
+}
+
+type es256Key struct {
+	key *ecdsa.PublicKey
+}
+
+func parseES256(m map[any]any) (PublicKey, error) {
+	crv, _ := m[int64(coseCrv)].(int64)
+	x, _ := m[int64(coseX)].([]byte)
+	y, _ := m[int64(coseY)].([]byte)
+
+	if crv != crvP256 || len(x) != 32 || len(y) != 32 {
+		return nil, fmt.Errorf("%w: invalid P-256 key", ErrUnsupportedKey)
+	}
+
+	// crypto/ecdh rejects points that are not on the curve.
+	point := append(append([]byte{4}, x...), y...)
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedKey, err)
+	}
+
+	return es256Key{key: &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}}, nil
+}
+
+func (k es256Key) Verify(data []byte, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	if !ecdsa.VerifyASN1(k.key, digest[:], signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type eddsaKey struct {
+	key ed25519.PublicKey
+}
+
+func parseEdDSA(m map[any]any) (PublicKey, error) {
+	crv, _ := m[int64(coseCrv)].(int64)
+	x, _ := m[int64(coseX)].([]byte)
+
+	if crv != crvEd25519 || len(x) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: invalid Ed25519 key", ErrUnsupportedKey)
+	}
+
+	return eddsaKey{key: ed25519.PublicKey(x)}, nil
+}
+
+func (k eddsaKey) Verify(data []byte, signature []byte) error {
+	if !ed25519.Verify(k.key, data, signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type rs256Key struct {
+	key *rsa.PublicKey
+}
+
+func parseRS256(m map[any]any) (PublicKey, error) {
+	n, _ := m[int64(coseN)].([]byte)
+	e, _ := m[int64(coseE)].([]byte)
+
+	if len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("%w: invalid RSA exponent", ErrUnsupportedKey)
+	}
+
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+
+	if key.N.BitLen() < minRSABits || key.E < 3 || key.E%2 == 0 {
+		return nil, fmt.Errorf("%w: weak RSA key", ErrUnsupportedKey)
+	}
+
+	return rs256Key{key: key}, nil
+}
+
+func (k rs256Key) Verify(data []byte, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	if err := rsa.VerifyPKCS1v15(k.key, crypto.SHA256, digest[:], signature); err != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
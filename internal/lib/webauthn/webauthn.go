@@ -0,0 +1,333 @@
+// Package webauthn verifies the registration and authentication ceremonies
+// of Web Authentication (https://www.w3.org/TR/webauthn-3/) for a relying
+// party.
+//
+// Attestation statements are not verified: the server does not trust or
+// distrust particular authenticator models, so it requests "none"
+// attestation and treats every statement as such.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sso/internal/lib/cbor"
+	"strings"
+)
+
+// Client data types of the two ceremonies.
+const (
+	TypeCreate = "webauthn.create"
+	TypeGet    = "webauthn.get"
+)
+
+// Authenticator data flags.
+const (
+	FlagUserPresent            = 0x01
+	FlagUserVerified           = 0x04
+	FlagAttestedCredentialData = 0x40
+	FlagExtensionData          = 0x80
+)
+
+const (
+	rpIDHashLen = 32
+	aaguidLen   = 16
+	// authDataLen is the length of the authenticator data without attested
+	// credential data and extensions.
+	authDataLen = rpIDHashLen + 1 + 4
+
+	maxCredentialIDLen = 1023
+)
+
+var (
+	ErrInvalidClientData        = errors.New("webauthn: invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("webauthn: invalid authenticator data")
+	ErrInvalidAttestation       = errors.New("webauthn: invalid attestation object")
+	ErrInvalidSignature         = errors.New("webauthn: invalid signature")
+	ErrUserNotVerified          = errors.New("webauthn: user not verified")
+	ErrInvalidOrigin            = errors.New("webauthn: invalid origin")
+)
+
+// RelyingParty is the party credentials are scoped to, identified by a
+// domain such as example.com.
+type RelyingParty struct {
+	ID   string
+	Name string
+	// Origins are the origins allowed to run the ceremonies. Only
+	// https://<ID> is allowed when empty.
+	Origins []string
+}
+
+// checkOrigin accepts only the origins allowed for the relying party.
+func (rp RelyingParty) checkOrigin(origin string) error {
+	origins := rp.Origins
+
+	if len(origins) == 0 {
+		origins = []string{"https://" + rp.ID}
+	}
+
+	if !slices.Contains(origins, origin) {
+		return fmt.Errorf("%w: origin %q is not allowed", ErrInvalidClientData, origin)
+	}
+
+	return nil
+}
+
+// ValidateOrigin reports whether origin can be allowed for the RP ID: an
+// HTTPS origin of the RP ID or of its subdomain, or http://localhost for
+// development.
+func ValidateOrigin(rpID string, origin string) error {
+	u, err := url.Parse(origin)
+
+	if err != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil || u.String() != origin {
+		return fmt.Errorf("%w: origin %q", ErrInvalidOrigin, origin)
+	}
+
+	host := u.Hostname()
+
+	if u.Scheme != "https" && !(u.Scheme == "http" && host == "localhost") {
+		return fmt.Errorf("%w: origin %q is not secure", ErrInvalidOrigin, origin)
+	}
+
+	if host != rpID && !strings.HasSuffix(host, "."+rpID) {
+		return fmt.Errorf("%w: origin %q does not match the RP ID", ErrInvalidOrigin, origin)
+	}
+
+	return nil
+}
+
+// ClientData is the part of CollectedClientData the server checks.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+func (rp RelyingParty) verifyClientData(clientDataJSON []byte, typ string, challenge string) error {
+	var clientData ClientData
+
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidClientData, err)
+	}
+
+	if clientData.Type != typ {
+		return fmt.Errorf("%w: type %q", ErrInvalidClientData, clientData.Type)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidClientData)
+	}
+
+	if clientData.CrossOrigin {
+		return fmt.Errorf("%w: cross-origin ceremony", ErrInvalidClientData)
+	}
+
+	return rp.checkOrigin(clientData.Origin)
+}
+
+// AuthenticatorData is the parsed authenticator data. The credential fields
+// are set only in the registration ceremony.
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	CredentialID []byte
+	// PublicKey is the COSE_Key of the credential.
+	PublicKey []byte
+}
+
+// ParseAuthenticatorData parses authenticator data, including the attested
+// credential data when its flag is set.
+func ParseAuthenticatorData(data []byte) (AuthenticatorData, error) {
+	if len(data) < authDataLen {
+		return AuthenticatorData{}, fmt.Errorf("%w: too short", ErrInvalidAuthenticatorData)
+	}
+
+	authData := AuthenticatorData{
+		RPIDHash:  data[:rpIDHashLen],
+		Flags:     data[rpIDHashLen],
+		SignCount: binary.BigEndian.Uint32(data[rpIDHashLen+1:]),
+	}
+
+	rest := data[authDataLen:]
+
+	if authData.Flags&FlagAttestedCredentialData != 0 {
+		if len(rest) < aaguidLen+2 {
+			return AuthenticatorData{}, fmt.Errorf("%w: truncated credential data", ErrInvalidAuthenticatorData)
+		}
+
+		rest = rest[aaguidLen:]
+		idLen := int(binary.BigEndian.Uint16(rest))
+		rest = rest[2:]
+
+		if idLen == 0 || idLen > maxCredentialIDLen || len(rest) < idLen {
+			return AuthenticatorData{}, fmt.Errorf("%w: invalid credential id", ErrInvalidAuthenticatorData)
+		}
+
+		authData.CredentialID = rest[:idLen]
+		rest = rest[idLen:]
+
+		var err error
+
+		tail := rest
+		if _, rest, err = cbor.Decode(rest); err != nil {
+			return AuthenticatorData{}, fmt.Errorf("%w: %w", ErrInvalidAuthenticatorData, err)
+		}
+
+		authData.PublicKey = tail[:len(tail)-len(rest)]
+	}
+
+	if authData.Flags&FlagExtensionData != 0 {
+		var err error
+
+		if _, rest, err = cbor.Decode(rest); err != nil {
+			return AuthenticatorData{}, fmt.Errorf("%w: %w", ErrInvalidAuthenticatorData, err)
+		}
+	}
+
+	if len(rest) != 0 {
+		return AuthenticatorData{}, fmt.Errorf("%w: trailing data", ErrInvalidAuthenticatorData)
+	}
+
+	return authData, nil
+}
+
+func (rp RelyingParty) verifyAuthenticatorData(authData AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return fmt.Errorf("%w: RP ID hash mismatch", ErrInvalidAuthenticatorData)
+	}
+
+	if authData.Flags&FlagUserPresent == 0 {
+		return fmt.Errorf("%w: user not present", ErrInvalidAuthenticatorData)
+	}
+
+	// Passwordless login relies on the authenticator verifying the user,
+	// e.g. with a PIN or biometrics.
+	if authData.Flags&FlagUserVerified == 0 {
+		return ErrUserNotVerified
+	}
+
+	return nil
+}
+
+// Credential is a registered public key credential.
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	SignCount uint32
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create()
+// to the options with the challenge and returns the new credential.
+func (rp RelyingParty) VerifyRegistration(
+	challenge string,
+	clientDataJSON []byte,
+	attestationObject []byte,
+) (Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, TypeCreate, challenge); err != nil {
+		return Credential{}, err
+	}
+
+	v, err := cbor.Unmarshal(attestationObject)
+
+	if err != nil {
+		return Credential{}, fmt.Errorf("%w: %w", ErrInvalidAttestation, err)
+	}
+
+	attestation, ok := v.(map[any]any)
+
+	if !ok {
+		return Credential{}, fmt.Errorf("%w: not a map", ErrInvalidAttestation)
+	}
+
+	rawAuthData, ok := attestation["authData"].([]byte)
+
+	if !ok {
+		return Credential{}, fmt.Errorf("%w: missing authData", ErrInvalidAttestation)
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+
+	if err != nil {
+		return Credential{}, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return Credential{}, err
+	}
+
+	if authData.CredentialID == nil {
+		return Credential{}, fmt.Errorf("%w: no attested credential data", ErrInvalidAuthenticatorData)
+	}
+
+	if _, err := ParsePublicKey(authData.PublicKey); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:        bytes.Clone(authData.CredentialID),
+		PublicKey: bytes.Clone(authData.PublicKey),
+		SignCount: authData.SignCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get() to
+// the options with the challenge, signed by the credential with publicKey,
+// and returns the new signature counter of the credential.
+func (rp RelyingParty) VerifyAssertion(
+	challenge string,
+	publicKey []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, TypeGet, challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := ParseAuthenticatorData(authenticatorData)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(authenticatorData), clientDataHash[:]...)
+
+	if err := key.Verify(signed, signature); err != nil {
+		return 0, err
+	}
+
+	return authData.SignCount, nil
+}
+
+// EncodeID encodes credential IDs, user handles and challenges the way the
+// JSON serialization of WebAuthn does.
+func EncodeID(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeID decodes an ID encoded by EncodeID.
+func DecodeID(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,167 @@
+package webauthn_test
+
+import (
+	"testing"
+	"time"
+
+	"sso/internal/lib/webauthn"
+	"sso/internal/lib/webauthn/webauthntest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rp = webauthn.RelyingParty{
+	ID:      "example.com",
+	Name:    "Example",
+	Origins: []string{"https://example.com", "https://login.example.com"},
+}
+
+func register(t *testing.T, authenticator *webauthntest.Authenticator) webauthn.Credential {
+	t.Helper()
+
+	options := rp.NewCreationOptions("registration-challenge", []byte("user-id"), "user@example.com", nil, time.Minute)
+
+	response, err := authenticator.Create(options)
+	require.NoError(t, err)
+
+	credential, err := rp.VerifyRegistration("registration-challenge", response.ClientDataJSON, response.AttestationObject)
+	require.NoError(t, err)
+	assert.Equal(t, response.CredentialID, credential.ID)
+
+	return credential
+}
+
+func TestCeremonies(t *testing.T) {
+	authenticator := webauthntest.New("https://login.example.com")
+	credential := register(t, authenticator)
+
+	options := rp.NewRequestOptions("login-challenge", [][]byte{credential.ID}, time.Minute)
+
+	response, err := authenticator.Get(options)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("user-id"), response.UserHandle)
+
+	signCount, err := rp.VerifyAssertion(
+		"login-challenge",
+		credential.PublicKey,
+		response.ClientDataJSON,
+		response.AuthenticatorData,
+		response.Signature,
+	)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, signCount)
+
+	_, err = rp.VerifyAssertion(
+		"other-challenge",
+		credential.PublicKey,
+		response.ClientDataJSON,
+		response.AuthenticatorData,
+		response.Signature,
+	)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidClientData)
+
+	tampered := append([]byte(nil), response.Signature...)
+	tampered[len(tampered)-1] ^= 1
+
+	_, err = rp.VerifyAssertion(
+		"login-challenge",
+		credential.PublicKey,
+		response.ClientDataJSON,
+		response.AuthenticatorData,
+		tampered,
+	)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+}
+
+func TestVerifyRegistration_WrongOrigin(t *testing.T) {
+	for _, origin := range []string{
+		"https://example.org",
+		"https://evilexample.com",
+		"http://example.com",
+		"https://evil.example.com",
+		"https://login.example.com:8443",
+	} {
+		authenticator := webauthntest.New(origin)
+
+		response, err := authenticator.Create(rp.NewCreationOptions("challenge", []byte("user-id"), "user", nil, time.Minute))
+		require.NoError(t, err)
+
+		_, err = rp.VerifyRegistration("challenge", response.ClientDataJSON, response.AttestationObject)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidClientData, origin)
+	}
+}
+
+func TestVerifyAssertion_WrongRP(t *testing.T) {
+	authenticator := webauthntest.New("https://example.com")
+	credential := register(t, authenticator)
+
+	response, err := authenticator.Get(rp.NewRequestOptions("challenge", nil, time.Minute))
+	require.NoError(t, err)
+
+	_, err = webauthn.RelyingParty{ID: "other.example.com"}.VerifyAssertion(
+		"challenge",
+		credential.PublicKey,
+		response.ClientDataJSON,
+		response.AuthenticatorData,
+		response.Signature,
+	)
+	assert.ErrorIs(t, err, webauthn.ErrInvalidClientData)
+}
+
+func TestCheckOrigin_DefaultsToRPID(t *testing.T) {
+	rp := webauthn.RelyingParty{ID: "example.com"}
+
+	for origin, ok := range map[string]bool{
+		"https://example.com":       true,
+		"https://login.example.com": false,
+	} {
+		authenticator := webauthntest.New(origin)
+
+		response, err := authenticator.Create(rp.NewCreationOptions("challenge", []byte("user-id"), "user", nil, time.Minute))
+		require.NoError(t, err)
+
+		_, err = rp.VerifyRegistration("challenge", response.ClientDataJSON, response.AttestationObject)
+		if ok {
+			assert.NoError(t, err, origin)
+		} else {
+			assert.ErrorIs(t, err, webauthn.ErrInvalidClientData, origin)
+		}
+	}
+}
+
+func TestValidateOrigin(t *testing.T) {
+	tests := []struct {
+		origin string
+		ok     bool
+	}{
+		{origin: "https://example.com", ok: true},
+		{origin: "https://login.example.com", ok: true},
+		{origin: "https://login.example.com:8443", ok: true},
+		{origin: "http://localhost:3000"},
+		{origin: "http://example.com"},
+		{origin: "https://example.org"},
+		{origin: "https://evilexample.com"},
+		{origin: "https://example.com/"},
+		{origin: "https://example.com/login"},
+		{origin: "https://user@example.com"},
+		{origin: "https://example.com?a=b"},
+		{origin: "example.com"},
+		{origin: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			err := webauthn.ValidateOrigin("example.com", tt.origin)
+			if tt.ok {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, webauthn.ErrInvalidOrigin)
+		})
+	}
+
+	assert.NoError(t, webauthn.ValidateOrigin("localhost", "http://localhost:3000"))
+}
@@ -0,0 +1,189 @@
+// Package webauthntest provides a software authenticator that performs the
+// WebAuthn ceremonies in tests, in place of a browser and a security key.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sso/internal/lib/cbor"
+	"sso/internal/lib/webauthn"
+)
+
+var ErrNoCredential = errors.New("webauthntest: no matching credential")
+
+// Authenticator holds ES256 credentials and answers the ceremonies as a
+// browser at Origin would. Every use of a credential increments its
+// signature counter.
+type Authenticator struct {
+	Origin string
+
+	credentials []*credential
+}
+
+type credential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// AttestationResponse is the AuthenticatorAttestationResponse of a
+// registration.
+type AttestationResponse struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// AssertionResponse is the AuthenticatorAssertionResponse of an
+// authentication.
+type AssertionResponse struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+func New(origin string) *Authenticator {
+	return &Authenticator{Origin: origin}
+}
+
+// Create creates a credential for the options of a registration ceremony.
+func (a *Authenticator) Create(options webauthn.CreationOptions) (AttestationResponse, error) {
+	userHandle, err := base64.RawURLEncoding.DecodeString(options.User.ID)
+	if err != nil {
+		return AttestationResponse{}, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return AttestationResponse{}, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return AttestationResponse{}, err
+	}
+
+	cred := &credential{id: id, rpID: options.RP.ID, userHandle: userHandle, key: key}
+
+	coseKey, err := cbor.Marshal(map[int]any{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return AttestationResponse{}, err
+	}
+
+	credentialData := make([]byte, 16) // AAGUID of a software authenticator
+	credentialData = binary.BigEndian.AppendUint16(credentialData, uint16(len(id)))
+	credentialData = append(credentialData, id...)
+	credentialData = append(credentialData, coseKey...)
+
+	authData := cred.authenticatorData(webauthn.FlagAttestedCredentialData)
+	authData = append(authData, credentialData...)
+
+	attestationObject, err := cbor.Marshal(map[any]any{
+		"fmt":      "none",
+		"attStmt":  map[any]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		return AttestationResponse{}, err
+	}
+
+	clientDataJSON, err := a.clientData(webauthn.TypeCreate, options.Challenge)
+	if err != nil {
+		return AttestationResponse{}, err
+	}
+
+	a.credentials = append(a.credentials, cred)
+
+	return AttestationResponse{
+		CredentialID:      id,
+		ClientDataJSON:    clientDataJSON,
+		AttestationObject: attestationObject,
+	}, nil
+}
+
+// Get signs the challenge of an authentication ceremony with the first
+// credential the options allow.
+func (a *Authenticator) Get(options webauthn.RequestOptions) (AssertionResponse, error) {
+	cred := a.find(options)
+	if cred == nil {
+		return AssertionResponse{}, ErrNoCredential
+	}
+
+	cred.signCount++
+
+	clientDataJSON, err := a.clientData(webauthn.TypeGet, options.Challenge)
+	if err != nil {
+		return AssertionResponse{}, err
+	}
+
+	authData := cred.authenticatorData(0)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, digest[:])
+	if err != nil {
+		return AssertionResponse{}, err
+	}
+
+	return AssertionResponse{
+		CredentialID:      cred.id,
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         signature,
+		UserHandle:        cred.userHandle,
+	}, nil
+}
+
+func (a *Authenticator) find(options webauthn.RequestOptions) *credential {
+	for _, cred := range a.credentials {
+		if cred.rpID != options.RPID {
+			continue
+		}
+
+		if len(options.AllowCredentials) == 0 {
+			return cred
+		}
+
+		for _, allowed := range options.AllowCredentials {
+			if allowed.ID == webauthn.EncodeID(cred.id) {
+				return cred
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) clientData(typ string, challenge string) ([]byte, error) {
+	return json.Marshal(webauthn.ClientData{
+		Type:      typ,
+		Challenge: challenge,
+		Origin:    a.Origin,
+	})
+}
+
+// authenticatorData returns the authenticator data without attested
+// credential data, with the user present and verified.
+func (c *credential) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+
+	data := append(rpIDHash[:], webauthn.FlagUserPresent|webauthn.FlagUserVerified|flags)
+
+	return binary.BigEndian.AppendUint32(data, c.signCount)
+}
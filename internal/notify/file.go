@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// File writes messages to a file, or to stdout, instead of sending them. It
+// is meant for development, where the messages are read by hand.
+type File struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Stdout is the path of NewFile that writes to stdout.
+const Stdout = "-"
+
+// NewFile returns a backend appending messages to the file at path, which is
+// created when missing. The path Stdout writes to stdout.
+func NewFile(path string) (*File, error) {
+	switch path {
+	case "":
+		return nil, errors.New(`notify: file path is empty, use "-" for stdout`)
+	case Stdout:
+		return &File{w: os.Stdout}, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{w: f}, nil
+}
+
+// Send appends the message followed by an empty line.
+func (f *File) Send(_ context.Context, msg Message) error {
+	data, err := format(msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err = f.w.Write(append(data, "\r\n\r\n"...))
+
+	return err
+}
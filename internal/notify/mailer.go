@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Mailer renders the messages of the service and sends them through a
+// Notifier. The locale of a message is taken from the context, see
+// WithLocale.
+type Mailer struct {
+	notifier  Notifier
+	templates *Templates
+	from      string
+}
+
+func NewMailer(notifier Notifier, templates *Templates, from string) *Mailer {
+	return &Mailer{
+		notifier:  notifier,
+		templates: templates,
+		from:      from,
+	}
+}
+
+// templateData is what the templates can refer to. Code and Link are only
+// set in email login messages.
+type templateData struct {
+	Email string
+	AppID string
+	Token string
+	Code  string
+	Link  string
+}
+
+// SendPasswordReset sends the password reset token to the user.
+func (m *Mailer) SendPasswordReset(ctx context.Context, email string, appID string, token string) error {
+	return m.send(ctx, KindPasswordReset, templateData{Email: email, AppID: appID, Token: token})
+}
+
+// SendEmailVerification sends the email verification token to the user.
+func (m *Mailer) SendEmailVerification(ctx context.Context, email string, appID string, token string) error {
+	return m.send(ctx, KindEmailVerification, templateData{Email: email, AppID: appID, Token: token})
+}
+
+// SendEmailLogin sends the code and the magic link of an email login to the
+// user. The token of the link is available to templates as well, so that an
+// app template can point to a page of its own.
+func (m *Mailer) SendEmailLogin(
+	ctx context.Context,
+	email string,
+	appID string,
+	code string,
+	link string,
+	token string,
+) error {
+	return m.send(ctx, KindEmailLogin, templateData{
+		Email: email,
+		AppID: appID,
+		Token: token,
+		Code:  code,
+		Link:  link,
+	})
+}
+
+func (m *Mailer) send(ctx context.Context, kind string, data templateData) error {
+	subject, body, err := m.templates.Render(data.AppID, kind, Locale(ctx), data)
+	if err != nil {
+		return fmt.Errorf("notify: render %s: %w", kind, err)
+	}
+
+	return m.notifier.Send(ctx, Message{
+		From:    m.from,
+		To:      data.Email,
+		Subject: subject,
+		Body:    body,
+	})
+}
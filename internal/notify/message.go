@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"bytes"
+	"mime"
+	"mime/quotedprintable"
+	"time"
+)
+
+// format renders the message as an RFC 5322 email with CRLF line endings.
+func format(msg Message, date time.Time) ([]byte, error) {
+	if err := checkHeaders(msg); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+
+	b.WriteString("From: " + msg.From + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	b.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+
+	if _, err := w.Write(bytes.ReplaceAll([]byte(msg.Body), []byte("\n"), []byte("\r\n"))); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,82 @@
+// Package notify delivers messages to users out of band. A Mailer renders
+// the messages of the service from templates and hands them to a Notifier
+// backend, which sends them by SMTP or writes them to a file for
+// development.
+package notify
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidHeader = errors.New("notify: header contains a line break")
+
+// Message is a plain text email.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier is a backend that delivers messages.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type localeKey struct{}
+
+// WithLocale returns ctx carrying the locale the messages sent while handling
+// the request are rendered in.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale set by WithLocale, or "" when there is none.
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+
+	return locale
+}
+
+// ParseAcceptLanguage returns the primary language of the most preferred
+// entry of an Accept-Language header, e.g. "ru" for "ru-RU,ru;q=0.9,en;q=0.8".
+func ParseAcceptLanguage(header string) string {
+	best, bestQ := "", -1.0
+
+	for _, entry := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		q := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			q = parsed
+		}
+
+		if tag == "" || tag == "*" || q <= bestQ {
+			continue
+		}
+
+		best, bestQ = tag, q
+	}
+
+	language, _, _ := strings.Cut(best, "-")
+
+	return strings.ToLower(language)
+}
+
+func checkHeaders(msg Message) error {
+	for _, value := range []string{msg.From, msg.To, msg.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return ErrInvalidHeader
+		}
+	}
+
+	return nil
+}
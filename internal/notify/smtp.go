@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Security modes of SMTP connections.
+const (
+	// SecurityStartTLS upgrades the connection with STARTTLS and refuses to
+	// send to servers that do not offer it.
+	SecurityStartTLS = "starttls"
+	// SecurityTLS connects with TLS from the start, usually on port 465.
+	SecurityTLS = "tls"
+	// SecurityNone sends in plain text, e.g. to a relay on localhost.
+	SecurityNone = "none"
+)
+
+var (
+	ErrUnknownSecurity = errors.New("unknown smtp security mode")
+	ErrNoStartTLS      = errors.New("smtp server does not support STARTTLS")
+)
+
+// SMTP sends messages through an SMTP server. The connection uses TLS
+// unless it is turned off with SecurityNone, and credentials are only sent
+// over TLS or to localhost, see smtp.PlainAuth.
+type SMTP struct {
+	host     string
+	addr     string
+	auth     smtp.Auth
+	tls      *tls.Config
+	security string
+}
+
+// NewSMTP returns a backend for the server at host:port using one of the
+// Security modes. The username may be empty for servers that do not require
+// authentication.
+func NewSMTP(host string, port int, username string, password string, security string) (*SMTP, error) {
+	switch security {
+	case SecurityStartTLS, SecurityTLS, SecurityNone:
+	default:
+		return nil, fmt.Errorf("notify: %w %q", ErrUnknownSecurity, security)
+	}
+
+	s := &SMTP{
+		host:     host,
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		tls:      &tls.Config{ServerName: host},
+		security: security,
+	}
+
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s, nil
+}
+
+// Send delivers the message. The deadline of ctx applies to the whole SMTP
+// session.
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	data, err := format(msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("notify: smtp dial: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.deliver(client, msg, data); err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTP) dial(ctx context.Context) (net.Conn, error) {
+	if s.security == SecurityTLS {
+		dialer := tls.Dialer{Config: s.tls}
+
+		return dialer.DialContext(ctx, "tcp", s.addr)
+	}
+
+	var dialer net.Dialer
+
+	return dialer.DialContext(ctx, "tcp", s.addr)
+}
+
+func (s *SMTP) deliver(client *smtp.Client, msg Message, data []byte) error {
+	if s.security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return ErrNoStartTLS
+		}
+
+		if err := client.StartTLS(s.tls); err != nil {
+			return err
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(msg.From); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,268 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP is an SMTP server that accepts every message without TLS or
+// authentication and records what it receives.
+type fakeSMTP struct {
+	listener net.Listener
+	messages chan received
+}
+
+type received struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSMTP{listener: listener, messages: make(chan received, 1)}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	msg := received{}
+
+	_ = tp.PrintfLine("220 localhost fake SMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			msg.data = string(data)
+			s.messages <- msg
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// newSMTP returns a backend for the server, which does not support TLS.
+func newSMTP(t *testing.T, server *fakeSMTP) *SMTP {
+	t.Helper()
+
+	backend, err := NewSMTP("127.0.0.1", server.port(), "", "", SecurityNone)
+	require.NoError(t, err)
+
+	return backend
+}
+
+func (s *fakeSMTP) receive(t *testing.T) received {
+	t.Helper()
+
+	select {
+	case msg := <-s.messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+
+		return received{}
+	}
+}
+
+// parse returns the decoded subject and body of the message.
+func parse(t *testing.T, data string) (string, string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.NoError(t, err)
+
+	return subject, string(body)
+}
+
+func TestMailer_SMTP(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	templates, err := LoadTemplates("", "en")
+	require.NoError(t, err)
+
+	mailer := NewMailer(newSMTP(t, server), templates, "sso@example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = mailer.SendPasswordReset(WithLocale(ctx, "ru"), "user@example.com", "app", "reset-token")
+	require.NoError(t, err)
+
+	msg := server.receive(t)
+	assert.Equal(t, "sso@example.com", msg.from)
+	assert.Equal(t, []string{"user@example.com"}, msg.to)
+
+	subject, body := parse(t, msg.data)
+	assert.Equal(t, "Сброс пароля", subject)
+	assert.Contains(t, body, "reset-token")
+
+	// Locales without templates fall back to the default locale.
+	err = mailer.SendEmailVerification(WithLocale(ctx, "de"), "user@example.com", "app", "verification-token")
+	require.NoError(t, err)
+
+	subject, body = parse(t, server.receive(t).data)
+	assert.Equal(t, "Verify your email", subject)
+	assert.Contains(t, body, "verification-token")
+
+	link := "https://app.example.com/login/email?token=login-token"
+
+	err = mailer.SendEmailLogin(ctx, "user@example.com", "app", "042137", link, "login-token")
+	require.NoError(t, err)
+
+	subject, body = parse(t, server.receive(t).data)
+	assert.Equal(t, "Your sign-in code", subject)
+	assert.Contains(t, body, "042137")
+	assert.Contains(t, body, link)
+}
+
+func TestMailer_AppTemplate(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "branded"), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "branded", KindPasswordReset+".en.tmpl"),
+		[]byte(`{{define "subject"}}Branded reset{{end}}{{define "body"}}https://branded.example.com/reset?token={{.Token}}{{end}}`),
+		0o600,
+	))
+
+	templates, err := LoadTemplates(dir, "en")
+	require.NoError(t, err)
+
+	mailer := NewMailer(newSMTP(t, server), templates, "sso@example.com")
+
+	require.NoError(t, mailer.SendPasswordReset(context.Background(), "user@example.com", "branded", "token"))
+
+	subject, body := parse(t, server.receive(t).data)
+	assert.Equal(t, "Branded reset", subject)
+	assert.Equal(t, "https://branded.example.com/reset?token=token\n", body)
+
+	require.NoError(t, mailer.SendPasswordReset(context.Background(), "user@example.com", "other", "token"))
+
+	subject, _ = parse(t, server.receive(t).data)
+	assert.Equal(t, "Reset your password", subject)
+}
+
+func TestSMTP_RejectsHeaderInjection(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	err := newSMTP(t, server).Send(context.Background(), Message{
+		From:    "sso@example.com",
+		To:      "user@example.com\r\nBcc: victim@example.com",
+		Subject: "subject",
+		Body:    "body",
+	})
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestSMTP_RequiresTLS(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	backend, err := NewSMTP("127.0.0.1", server.port(), "", "", SecurityStartTLS)
+	require.NoError(t, err)
+
+	err = backend.Send(context.Background(), Message{
+		From:    "sso@example.com",
+		To:      "user@example.com",
+		Subject: "subject",
+		Body:    "body",
+	})
+	assert.ErrorIs(t, err, ErrNoStartTLS)
+
+	select {
+	case <-server.messages:
+		t.Fatal("message sent without TLS")
+	default:
+	}
+
+	_, err = NewSMTP("127.0.0.1", server.port(), "", "", "")
+	assert.ErrorIs(t, err, ErrUnknownSecurity)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                        "",
+		"ru":                      "ru",
+		"ru-RU,ru;q=0.9,en;q=0.8": "ru",
+		"en;q=0.5, de-DE":         "de",
+		"*, fr;q=0.1":             "fr",
+		"EN-us;q=0.7,pl;q=broken": "en",
+	}
+
+	for header, want := range tests {
+		assert.Equal(t, want, ParseAcceptLanguage(header), strconv.Quote(header))
+	}
+}
@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// Kinds of messages sent by the service.
+const (
+	KindPasswordReset     = "password_reset"
+	KindEmailVerification = "email_verification"
+	KindEmailLogin        = "email_login"
+)
+
+var ErrTemplateNotFound = errors.New("notify: template not found")
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// Templates holds the message templates per app, kind and locale. Every
+// template defines a "subject" and a "body" template.
+//
+// The built-in templates are overridden by templates from a directory laid
+// out as
+//
+//	<kind>.<locale>.tmpl           overrides for every app
+//	<app_id>/<kind>.<locale>.tmpl  overrides for one app
+type Templates struct {
+	defaultLocale string
+	// templates are keyed by "<app_id>/<kind>.<locale>", with an empty
+	// app_id for the templates of every app.
+	templates map[string]*template.Template
+}
+
+// LoadTemplates loads the built-in templates and the overrides from dir,
+// which may be empty. Messages in a locale without a template are rendered
+// in defaultLocale.
+func LoadTemplates(dir string, defaultLocale string) (*Templates, error) {
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		templates:     map[string]*template.Template{},
+	}
+
+	builtIn, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.load(builtIn); err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		if err := t.load(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Templates) load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if strings.Count(name, "/") > 0 {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if path.Ext(name) != ".tmpl" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return fmt.Errorf("notify: parse template %s: %w", name, err)
+		}
+
+		for _, part := range []string{"subject", "body"} {
+			if tmpl.Lookup(part) == nil {
+				return fmt.Errorf("notify: template %s does not define %q", name, part)
+			}
+		}
+
+		appID := path.Dir(name)
+		if appID == "." {
+			appID = ""
+		}
+
+		t.templates[appID+"/"+strings.TrimSuffix(path.Base(name), ".tmpl")] = tmpl
+
+		return nil
+	})
+}
+
+// Render renders the subject and the body of a message of the kind for the
+// app. The template of the app is preferred over the one of every app, and
+// the locale over the default locale.
+func (t *Templates) Render(appID string, kind string, locale string, data any) (string, string, error) {
+	tmpl := t.lookup(appID, kind, locale)
+
+	if tmpl == nil {
+		return "", "", fmt.Errorf("%w: %s", ErrTemplateNotFound, kind)
+	}
+
+	var subject, body strings.Builder
+
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimSpace(body.String()) + "\n", nil
+}
+
+func (t *Templates) lookup(appID string, kind string, locale string) *template.Template {
+	for _, app := range []string{appID, ""} {
+		for _, loc := range []string{locale, t.defaultLocale} {
+			if loc == "" {
+				continue
+			}
+
+			if tmpl, ok := t.templates[app+"/"+kind+"."+loc]; ok {
+				return tmpl
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrAdminPasswordRequired = errors.New("admin password is required")
+
+// adminAppAlgorithm is the signing algorithm of the admin app. Admin tokens
+// must be signed with a server key to be accepted, see VerifyUserToken.
+const adminAppAlgorithm = jwt.AlgES256
+
+// EnsureAdmin bootstraps the administrator: it creates the admin app with the
+// given ID and the user with the email when they do not exist yet, makes the
+// user a member of the app and grants it admin rights. The password of a new
+// user must satisfy the default password policy; the password of an existing
+// user is left untouched. An admin app signing with HS256, as
+// created by older versions, is switched to a server key.
+func (a *Auth) EnsureAdmin(ctx context.Context, email string, password string, appID string) error {
+	const op = "services.auth.EnsureAdmin"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if err := a.ensureAdminApp(ctx, appID); err != nil {
+		log.Error("failed to create admin app", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	switch {
+	case err == nil:
+		err = a.userSaver.AddMembership(ctx, user.ID, appID)
+		if err != nil && !errors.Is(err, storage.ErrMembershipExists) {
+			return fmt.Errorf("%s %w", op, err)
+		}
+	case errors.Is(err, storage.ErrUserNotFound):
+		if password == "" {
+			return fmt.Errorf("%s %w", op, ErrAdminPasswordRequired)
+		}
+
+		if err := checkPassword(log, a.defaultPasswordPolicy, password, email); err != nil {
+			return fmt.Errorf("%s admin %w", op, describePolicyError(err))
+		}
+
+		passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		user.ID, err = a.userSaver.SaverUser(ctx, email, passHash, appID)
+
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		log.Info("admin user created")
+	default:
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.userSaver.SetAdmin(ctx, user.ID, true); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("admin ensured", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+func (a *Auth) ensureAdminApp(ctx context.Context, appID string) error {
+	app, err := a.appProvider.App(ctx, appID)
+
+	if err == nil {
+		if jwt.IsAsymmetricAlgorithm(jwt.Algorithm(app)) {
+			return nil
+		}
+
+		alg := adminAppAlgorithm
+
+		return a.appSaver.UpdateApp(ctx, appID, models.AppUpdate{SigningAlgorithm: &alg})
+	}
+
+	if !errors.Is(err, storage.ErrAppNotFound) {
+		return err
+	}
+
+	secret, err := opaque.NewToken()
+
+	if err != nil {
+		return err
+	}
+
+	app = models.App{ID: appID, Name: appID, Secret: secret, SigningAlgorithm: adminAppAlgorithm}
+
+	if err := a.protectAppSecrets(&app); err != nil {
+		return err
+	}
+
+	_, err = a.appSaver.SaveApp(ctx, app)
+
+	if err != nil && !errors.Is(err, storage.ErrAppExists) {
+		return err
+	}
+
+	return nil
+}
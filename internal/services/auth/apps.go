@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"strings"
+	"time"
+)
+
+var ErrInvalidAppName = errors.New("invalid app name")
+
+// GetApp returns the app with the given id.
+func (a *Auth) GetApp(ctx context.Context, appID string) (models.App, error) {
+	const op = "services.auth.GetApp"
+
+	app, err := a.appProvider.App(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return models.App{}, fmt.Errorf("%s %w", op, ErrAppNotFound)
+		}
+
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return app, nil
+}
+
+// ListApps returns a page of apps and the token of the next page, which is
+// empty on the last page.
+func (a *Auth) ListApps(ctx context.Context, pageSize int, pageToken string) ([]models.App, string, error) {
+	const op = "services.auth.ListApps"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	pageSize = normalizePageSize(pageSize)
+
+	after, err := decodePageToken(pageToken)
+
+	if err != nil {
+		log.Warn("invalid page token")
+
+		return nil, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	// One extra app tells whether there is a next page.
+	apps, err := a.appProvider.Apps(ctx, models.AppFilter{After: after, Limit: pageSize + 1})
+
+	if err != nil {
+		log.Error("failed to list apps", slog.String("error:", err.Error()))
+
+		return nil, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	var nextPageToken string
+
+	if len(apps) > pageSize {
+		apps = apps[:pageSize]
+		last := apps[pageSize-1]
+		nextPageToken = encodePageToken(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return apps, nextPageToken, nil
+}
+
+// UpdateApp changes the fields of the app set in the update and returns the
+// updated app.
+func (a *Auth) UpdateApp(ctx context.Context, appID string, update models.AppUpdate) (models.App, error) {
+	const op = "services.auth.UpdateApp"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if update.Name != nil && strings.TrimSpace(*update.Name) == "" {
+		return models.App{}, fmt.Errorf("%s %w", op, ErrInvalidAppName)
+	}
+
+	if update.RedirectURIs != nil {
+		if err := validateRedirectURIs(*update.RedirectURIs); err != nil {
+			log.Warn("invalid redirect uri")
+
+			return models.App{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	if update.AllowedScopes != nil {
+		if err := validateAllowedScopes(*update.AllowedScopes); err != nil {
+			log.Warn("invalid allowed scope")
+
+			return models.App{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	if update.WebAuthnRPID != nil || update.WebAuthnOrigins != nil {
+		if err := a.validateWebAuthnUpdate(ctx, appID, update); err != nil {
+			log.Warn("invalid webauthn settings", slog.String("error:", err.Error()))
+
+			return models.App{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	if err := validatePasswordPolicy(update.PasswordPolicy); err != nil {
+		log.Warn("invalid password policy")
+
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.appSaver.UpdateApp(ctx, appID, update); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrAppNotFound):
+			log.Warn("app not found")
+
+			return models.App{}, fmt.Errorf("%s %w", op, ErrAppNotFound)
+		case errors.Is(err, storage.ErrAppExists):
+			log.Warn("app name is taken")
+
+			return models.App{}, fmt.Errorf("%s %w", op, ErrAppExists)
+		}
+
+		log.Error("failed to update app", slog.String("error:", err.Error()))
+
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("app updated")
+
+	app, err := a.GetApp(ctx, appID)
+
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return app, nil
+}
+
+// DeleteApp soft deletes the app. Its users can no longer sign in to it,
+// tokens issued for it stop being valid and its refresh tokens are revoked.
+func (a *Auth) DeleteApp(ctx context.Context, appID string) error {
+	const op = "services.auth.DeleteApp"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if err := a.appSaver.DeleteApp(ctx, appID); err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found")
+
+			return fmt.Errorf("%s %w", op, ErrAppNotFound)
+		}
+
+		log.Error("failed to delete app", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("app deleted")
+
+	return nil
+}
+
+// RotateAppSecret replaces the secret of the app with the given one, or a
+// random one when it is empty. Until the returned time the previous secret
+// is still accepted for client authentication and HS256 tokens signed with
+// it stay valid. With revokePrevious the previous secret is dropped at once
+// and the returned time is zero.
+func (a *Auth) RotateAppSecret(
+	ctx context.Context,
+	appID string,
+	secret string,
+	revokePrevious bool,
+) (string, time.Time, error) {
+	const op = "services.auth.RotateAppSecret"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if secret == "" {
+		generated, err := opaque.NewToken()
+
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		secret = generated
+	}
+
+	secretHash, secretCiphertext, err := a.protectSecret(secret)
+
+	if err != nil {
+		log.Error("failed to protect app secret", slog.String("error:", err.Error()))
+
+		return "", time.Time{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	var previousExpiresAt time.Time
+
+	if !revokePrevious {
+		previousExpiresAt = time.Now().Add(a.appSecretGracePeriod)
+	}
+
+	err = a.appSaver.RotateAppSecret(ctx, appID, secretHash, secretCiphertext, previousExpiresAt)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found")
+
+			return "", time.Time{}, fmt.Errorf("%s %w", op, ErrAppNotFound)
+		}
+
+		log.Error("failed to rotate app secret", slog.String("error:", err.Error()))
+
+		return "", time.Time{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("app secret rotated",
+		slog.Time("previous_secret_expires_at", previousExpiresAt),
+		slog.Bool("previous_secret_revoked", revokePrevious),
+	)
+
+	return secret, previousExpiresAt, nil
+}
+
+func validateRedirectURIs(redirectURIs []string) error {
+	for _, redirectURI := range redirectURIs {
+		if !isValidRedirectURI(redirectURI) {
+			return ErrInvalidRedirectURI
+		}
+	}
+
+	return nil
+}
+
+func validateAllowedScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !isValidScopeToken(scope) {
+			return ErrInvalidScope
+		}
+	}
+
+	return nil
+}
@@ -19,15 +19,50 @@ var (
 	ErrUserExists         = errors.New("user already exists")
 	ErrAppExists          = errors.New("app already exists")
 	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidAlgorithm   = errors.New("invalid signing algorithm")
+	ErrNotMember          = errors.New("user is not a member of the app")
+	ErrAppNotFound        = errors.New("app not found")
+	ErrEmailNotVerified   = errors.New("email is not verified")
 )
 
 type Auth struct {
-	log          *slog.Logger
-	userSaver    UserSaver
-	userProvider UserProvider
-	appProvider  AppProvider
-	appSaver     AppSaver
-	tokenTTL     time.Duration
+	log                   *slog.Logger
+	userSaver             UserSaver
+	userProvider          UserProvider
+	appProvider           AppProvider
+	appSaver              AppSaver
+	refreshTokenSaver     RefreshTokenSaver
+	refreshTokenProvider  RefreshTokenProvider
+	tokenRevoker          TokenRevoker
+	keyProvider           KeyProvider
+	authCodeStorage       AuthorizationCodeStorage
+	deviceCodeStorage     DeviceCodeStorage
+	roleStorage           RoleStorage
+	sealer                SecretSealer
+	passwordResetStorage  PasswordResetStorage
+	emailVerifyStorage    EmailVerificationStorage
+	mfaStorage            MFAStorage
+	webAuthnStorage       WebAuthnStorage
+	emailLoginStorage     EmailLoginStorage
+	loginThrottleStorage  LoginThrottleStorage
+	notifier              Notifier
+	issuer                string
+	totpIssuer            string
+	emailLoginURL         string
+	tokenTTL              time.Duration
+	refreshTokenTTL       time.Duration
+	authCodeTTL           time.Duration
+	deviceCodeTTL         time.Duration
+	devicePollInterval    time.Duration
+	appSecretGracePeriod  time.Duration
+	passwordResetTTL      time.Duration
+	emailVerificationTTL  time.Duration
+	mfaChallengeTTL       time.Duration
+	webAuthnSessionTTL    time.Duration
+	emailLoginTTL         time.Duration
+	lockoutPolicy         LockoutPolicy
+	defaultPasswordPolicy models.PasswordPolicy
 }
 
 type UserSaver interface {
@@ -37,46 +72,273 @@ type UserSaver interface {
 		passHash []byte,
 		app_id string,
 	) (string, error)
+	AddMembership(ctx context.Context, userID string, appID string) error
+	SetAdmin(ctx context.Context, userID string, isAdmin bool) error
+	UpdateUser(ctx context.Context, userID string, update models.UserUpdate) error
+	DeleteUser(ctx context.Context, userID string) error
+	UpdatePassword(ctx context.Context, userID string, passHash []byte) error
 }
 
 type UserProvider interface {
 	User(ctx context.Context, email string) (models.User, error)
+	UserByID(ctx context.Context, userID string) (models.User, error)
 	IsAdmin(ctx context.Context, userID string) (bool, error)
+	IsMember(ctx context.Context, userID string, appID string) (bool, error)
+	Users(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	UserApps(ctx context.Context, userIDs []string) (map[string][]string, error)
 }
 
 type AppProvider interface {
 	App(ctx context.Context, appID string) (models.App, error)
+	Apps(ctx context.Context, filter models.AppFilter) ([]models.App, error)
 }
 
 type AppSaver interface {
-	SaveApp(ctx context.Context, name string, secret string) (string, error)
+	SaveApp(ctx context.Context, app models.App) (string, error)
+	UpdateApp(ctx context.Context, appID string, update models.AppUpdate) error
+	DeleteApp(ctx context.Context, appID string) error
+	RotateAppSecret(
+		ctx context.Context,
+		appID string,
+		secretHash string,
+		secretCiphertext string,
+		previousExpiresAt time.Time,
+	) error
+	MigrateLegacyAppSecrets(ctx context.Context, protect func(app *models.App) error) error
+}
+
+type RefreshTokenSaver interface {
+	SaveRefreshToken(ctx context.Context, token models.RefreshToken) error
+	UseRefreshToken(ctx context.Context, tokenID string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+}
+
+type RefreshTokenProvider interface {
+	RefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+}
+
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpiredRevokedTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+type KeyProvider interface {
+	SigningKey(ctx context.Context, alg string) (jwt.Key, error)
+	VerificationKey(ctx context.Context, kid string) (jwt.Key, error)
+}
+
+type AuthorizationCodeStorage interface {
+	SaveAuthorizationCode(ctx context.Context, code models.AuthorizationCode) error
+	AuthorizationCode(ctx context.Context, codeHash string) (models.AuthorizationCode, error)
+	UseAuthorizationCode(ctx context.Context, codeHash string, appID string, accessTokenID string, now time.Time) error
+	DeleteExpiredAuthorizationCodes(ctx context.Context, before time.Time) (int64, error)
+}
+
+type DeviceCodeStorage interface {
+	SaveDeviceCode(ctx context.Context, code models.DeviceCode) error
+	DeviceCodeByUserCode(ctx context.Context, userCode string, now time.Time) (models.DeviceCode, error)
+	DecideDeviceCode(ctx context.Context, userCode string, userID string, status string, now time.Time) error
+	PollDeviceCode(ctx context.Context, deviceCodeHash string, appID string, now time.Time) (models.DeviceCode, error)
+	UseDeviceCode(ctx context.Context, deviceCodeHash string, appID string, now time.Time) error
+	SlowDownDeviceCode(ctx context.Context, deviceCodeHash string, interval time.Duration) error
+	DeleteExpiredDeviceCodes(ctx context.Context, before time.Time) (int64, error)
+}
+
+type RoleStorage interface {
+	SaveRole(ctx context.Context, role models.Role) (string, error)
+	Role(ctx context.Context, roleID string) (models.Role, error)
+	AddRolePermission(ctx context.Context, roleID string, permission string) error
+	AssignRole(ctx context.Context, userID string, roleID string) error
+	UserAccess(ctx context.Context, userID string, appID string) (roles []string, permissions []string, err error)
+	HasPermission(ctx context.Context, userID string, appID string, permission string) (bool, error)
+}
+
+type PasswordResetStorage interface {
+	SavePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error
+	PasswordResetToken(ctx context.Context, tokenHash string, now time.Time) (models.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenHash string, passHash []byte, now time.Time) (userID string, err error)
+	DeleteExpiredPasswordResetTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+type EmailVerificationStorage interface {
+	SaveEmailVerificationToken(ctx context.Context, token models.EmailVerificationToken) error
+	VerifyEmail(ctx context.Context, tokenHash string, now time.Time) (userID string, err error)
+	DeleteExpiredEmailVerificationTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+type MFAStorage interface {
+	SaveTOTPSecret(ctx context.Context, userID string, secretCiphertext string) error
+	EnableTOTP(ctx context.Context, userID string, step int64) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID string, codeHash string, now time.Time) error
+	RecoveryCodesRemaining(ctx context.Context, userID string) (int, error)
+	SaveMFAChallenge(ctx context.Context, challenge models.MFAChallenge) error
+	MFAChallenge(ctx context.Context, idHash string, now time.Time) (models.MFAChallenge, error)
+	TakeMFAChallengeAttempt(ctx context.Context, idHash string, maxAttempts int) error
+	UseMFAChallenge(ctx context.Context, idHash string, now time.Time) error
+	DeleteExpiredMFAChallenges(ctx context.Context, before time.Time) (int64, error)
+}
+
+type WebAuthnStorage interface {
+	SaveWebAuthnSession(ctx context.Context, session models.WebAuthnSession) error
+	UseWebAuthnSession(ctx context.Context, idHash string, ceremony string, now time.Time) (models.WebAuthnSession, error)
+	DeleteExpiredWebAuthnSessions(ctx context.Context, before time.Time) (int64, error)
+	SaveWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) error
+	WebAuthnCredential(ctx context.Context, id string) (models.WebAuthnCredential, error)
+	WebAuthnCredentials(ctx context.Context, userID string, rpID string) ([]models.WebAuthnCredential, error)
+	UseWebAuthnCredential(ctx context.Context, id string, previousSignCount uint32, signCount uint32, now time.Time) error
+}
+
+type EmailLoginStorage interface {
+	SaveEmailLogin(ctx context.Context, login models.EmailLogin) error
+	UseEmailLoginCode(
+		ctx context.Context,
+		userID string,
+		appID string,
+		codeHash string,
+		maxAttempts int,
+		now time.Time,
+	) (models.EmailLogin, error)
+	UseEmailLoginLink(ctx context.Context, idHash string, now time.Time) (models.EmailLogin, error)
+	DeleteExpiredEmailLogins(ctx context.Context, before time.Time) (int64, error)
+}
+
+type LoginThrottleStorage interface {
+	LoginThrottles(ctx context.Context, keys []string) ([]models.LoginThrottle, error)
+	RecordLoginFailure(
+		ctx context.Context,
+		key string,
+		now time.Time,
+		window time.Duration,
+		lockoutThreshold int,
+		lockoutDuration time.Duration,
+	) (models.LoginThrottle, error)
+	ResetLoginFailures(ctx context.Context, key string) error
+	ThrottleCall(
+		ctx context.Context,
+		key string,
+		now time.Time,
+		window time.Duration,
+		interval time.Duration,
+		limit int,
+	) (time.Duration, error)
+	DeleteStaleLoginThrottles(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Notifier delivers messages to users out of band, e.g. by email.
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email string, appID string, token string) error
+	SendEmailVerification(ctx context.Context, email string, appID string, token string) error
+	SendEmailLogin(ctx context.Context, email string, appID string, code string, link string, token string) error
+}
+
+// Storage is everything the service keeps in the database.
+type Storage interface {
+	UserSaver
+	UserProvider
+	AppProvider
+	AppSaver
+	RefreshTokenSaver
+	RefreshTokenProvider
+	TokenRevoker
+	AuthorizationCodeStorage
+	DeviceCodeStorage
+	RoleStorage
+	PasswordResetStorage
+	EmailVerificationStorage
+	MFAStorage
+	WebAuthnStorage
+	EmailLoginStorage
+	LoginThrottleStorage
+}
+
+// Config holds the settings of the service. The TTLs are how long the tokens,
+// codes, challenges and sessions the service hands out stay valid.
+type Config struct {
+	// Issuer is the iss claim of ID tokens and TOTPIssuer the name of the
+	// service in authenticator apps.
+	Issuer     string
+	TOTPIssuer string
+	// EmailLoginURL is the page the magic links of email logins point to.
+	EmailLoginURL        string
+	TokenTTL             time.Duration
+	RefreshTokenTTL      time.Duration
+	AuthCodeTTL          time.Duration
+	DeviceCodeTTL        time.Duration
+	DevicePollInterval   time.Duration
+	PasswordResetTTL     time.Duration
+	EmailVerificationTTL time.Duration
+	MFAChallengeTTL      time.Duration
+	WebAuthnSessionTTL   time.Duration
+	EmailLoginTTL        time.Duration
+	// AppSecretGracePeriod is how long the previous secret of an app is
+	// accepted after the secret is rotated.
+	AppSecretGracePeriod  time.Duration
+	LockoutPolicy         LockoutPolicy
+	DefaultPasswordPolicy models.PasswordPolicy
 }
 
 // Create new entity of Auth
 func New(
 	log *slog.Logger,
-	userSaver UserSaver,
-	userProvider UserProvider,
-	appProvider AppProvider,
-	appSaver AppSaver,
-	tokenTTL time.Duration,
+	store Storage,
+	keyProvider KeyProvider,
+	sealer SecretSealer,
+	notifier Notifier,
+	cfg Config,
 ) *Auth {
 	return &Auth{
-		userSaver:    userSaver,
-		userProvider: userProvider,
-		appProvider:  appProvider,
-		appSaver:     appSaver,
-		log:          log,
-		tokenTTL:     tokenTTL,
+		userSaver:             store,
+		userProvider:          store,
+		appProvider:           store,
+		appSaver:              store,
+		refreshTokenSaver:     store,
+		refreshTokenProvider:  store,
+		tokenRevoker:          store,
+		keyProvider:           keyProvider,
+		authCodeStorage:       store,
+		deviceCodeStorage:     store,
+		roleStorage:           store,
+		sealer:                sealer,
+		passwordResetStorage:  store,
+		emailVerifyStorage:    store,
+		mfaStorage:            store,
+		webAuthnStorage:       store,
+		emailLoginStorage:     store,
+		loginThrottleStorage:  store,
+		notifier:              notifier,
+		issuer:                cfg.Issuer,
+		totpIssuer:            cfg.TOTPIssuer,
+		emailLoginURL:         cfg.EmailLoginURL,
+		log:                   log,
+		tokenTTL:              cfg.TokenTTL,
+		refreshTokenTTL:       cfg.RefreshTokenTTL,
+		authCodeTTL:           cfg.AuthCodeTTL,
+		deviceCodeTTL:         cfg.DeviceCodeTTL,
+		devicePollInterval:    cfg.DevicePollInterval,
+		appSecretGracePeriod:  cfg.AppSecretGracePeriod,
+		passwordResetTTL:      cfg.PasswordResetTTL,
+		emailVerificationTTL:  cfg.EmailVerificationTTL,
+		mfaChallengeTTL:       cfg.MFAChallengeTTL,
+		webAuthnSessionTTL:    cfg.WebAuthnSessionTTL,
+		emailLoginTTL:         cfg.EmailLoginTTL,
+		lockoutPolicy:         cfg.LockoutPolicy,
+		defaultPasswordPolicy: cfg.DefaultPasswordPolicy,
 	}
 }
 
+// Login checks the password of the user. Users with MFA enabled get no
+// tokens but the ID of an MFA challenge, which VerifyMFA completes with a
+// code.
 func (a *Auth) Login(
 	ctx context.Context,
 	email string,
 	password string,
 	appID string,
-) (string, error) {
+	nonce string,
+) (models.Tokens, string, error) {
 	const op = "services.auth.Login"
 
 	log := a.log.With(
@@ -86,50 +348,176 @@ func (a *Auth) Login(
 
 	log.Info("login user")
 
+	user, err := a.authenticate(ctx, log, email, password)
+
+	if err != nil {
+		return models.Tokens{}, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.app(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			a.log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, "", fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+		return models.Tokens{}, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, challengeID, err := a.completeLogin(ctx, log, user, app, nonce)
+
+	if err != nil {
+		return models.Tokens{}, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return tokens, challengeID, nil
+}
+
+// completeLogin issues tokens to a user who proved their identity, or starts
+// an MFA challenge for users with MFA enabled and returns its ID.
+func (a *Auth) completeLogin(
+	ctx context.Context,
+	log *slog.Logger,
+	user models.User,
+	app models.App,
+	nonce string,
+) (models.Tokens, string, error) {
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is not a member of the app")
+
+			return models.Tokens{}, "", ErrInvalidCredentials
+		}
+
+		return models.Tokens{}, "", err
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return models.Tokens{}, "", err
+	}
+
+	if user.TOTPEnabled {
+		challengeID, err := a.startMFAChallenge(ctx, user, app, nonce)
+
+		if err != nil {
+			log.Error("failed to start mfa challenge", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, "", err
+		}
+
+		log.Info("mfa challenge started", slog.String("user_id", user.ID))
+
+		return models.Tokens{}, challengeID, nil
+	}
+
+	log.Info("user logged in succesfully")
+
+	tokens, err := a.issueTokens(ctx, user, app, "", nonce)
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, "", err
+	}
+
+	return tokens, "", nil
+}
+
+// authenticate checks the user's password and returns the user. Failures are
+// throttled according to the lockout policy, see LockoutPolicy.
+func (a *Auth) authenticate(
+	ctx context.Context,
+	log *slog.Logger,
+	email string,
+	password string,
+) (models.User, error) {
+	if err := a.checkLoginThrottle(ctx, log, email); err != nil {
+		return models.User{}, err
+	}
+
 	user, err := a.userProvider.User(ctx, email)
 
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
-			a.log.Warn("user not found", slog.String("error:", err.Error()))
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			a.recordLoginFailure(ctx, log, email)
 
-			return "", fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+			return models.User{}, ErrInvalidCredentials
 		}
 
-		a.log.Error("failed to login user", slog.String("error:", err.Error()))
+		log.Error("failed to login user", slog.String("error:", err.Error()))
 
-		return "", fmt.Errorf("%s %w", op, err)
+		return models.User{}, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword(user.Passhash, []byte(password)); err != nil {
-		a.log.Info("Invalid credentials", slog.String("error:", err.Error()))
+		log.Info("Invalid credentials", slog.String("error:", err.Error()))
 
-		return "", fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		a.recordLoginFailure(ctx, log, email)
+
+		return models.User{}, ErrInvalidCredentials
 	}
 
-	app, err := a.appProvider.App(ctx, appID)
+	a.resetLoginFailures(ctx, log, email)
+
+	return user, nil
+}
+
+// tokenUser returns the owner of a user access token and its claims.
+func (a *Auth) tokenUser(ctx context.Context, log *slog.Logger, accessToken string) (models.User, jwt.Claims, error) {
+	claims, err := a.VerifyUserToken(ctx, accessToken)
 
 	if err != nil {
-		if errors.Is(err, storage.ErrAppNotFound) {
-			a.log.Warn("invalid app id", slog.String("error:", err.Error()))
+		log.Warn("failed to verify access token", slog.String("error:", err.Error()))
 
-			return "", fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		return models.User{}, jwt.Claims{}, err
+	}
+
+	if claims.UID == "" {
+		log.Warn("access token has no user subject")
+
+		return models.User{}, jwt.Claims{}, ErrInvalidToken
+	}
+
+	user, err := a.userProvider.UserByID(ctx, claims.UID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.User{}, jwt.Claims{}, ErrInvalidToken
 		}
-		return "", fmt.Errorf("%s %w", op, err)
+
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return models.User{}, jwt.Claims{}, err
 	}
 
-	log.Info("user logged in succesfully")
+	return user, claims, nil
+}
 
-	token, err := jwt.NewToken(user, app, a.tokenTTL)
+// checkMembership returns ErrNotMember unless the user is a member of the app.
+func (a *Auth) checkMembership(ctx context.Context, userID string, appID string) error {
+	isMember, err := a.userProvider.IsMember(ctx, userID, appID)
 
 	if err != nil {
-		a.log.Error("failed to generate token", slog.String("error:", err.Error()))
+		return err
+	}
 
-		return "", fmt.Errorf("%s %w", op, err)
+	if !isMember {
+		return ErrNotMember
 	}
 
-	return token, nil
+	return nil
 }
 
+// RegisterNewUser registers the user in the app. If an identity with the
+// email already exists and the password matches it, the identity is made a
+// member of the app instead of creating a new one.
 func (a *Auth) RegisterNewUser(
 	ctx context.Context,
 	email string,
@@ -145,6 +533,40 @@ func (a *Auth) RegisterNewUser(
 
 	log.Info("registering user")
 
+	if err := validateEmail(email); err != nil {
+		log.Warn("invalid email")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.appProvider.App(ctx, app_id)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return "", fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	if err == nil {
+		return a.addMember(ctx, log, user, password, app_id)
+	}
+
+	if !errors.Is(err, storage.ErrUserNotFound) {
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkPassword(log, a.passwordPolicy(app), password, email); err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
 	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 
 	if err != nil {
@@ -167,9 +589,58 @@ func (a *Auth) RegisterNewUser(
 		return "", fmt.Errorf("%s %w", op, err)
 	}
 
+	a.sendEmailVerification(ctx, log, models.User{ID: id, Email: email}, app_id)
+
 	return id, nil
 }
 
+// addMember links an existing identity to another app. The password must
+// match, so nobody can join an identity they do not own to an app. Wrong
+// passwords count as failed logins, see LockoutPolicy.
+func (a *Auth) addMember(
+	ctx context.Context,
+	log *slog.Logger,
+	user models.User,
+	password string,
+	appID string,
+) (string, error) {
+	const op = "services.auth.addMember"
+
+	if err := a.checkLoginThrottle(ctx, log, user.Email); err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.Passhash, []byte(password)); err != nil {
+		log.Warn("user already exists")
+
+		a.recordLoginFailure(ctx, log, user.Email)
+
+		return "", fmt.Errorf("%s %w", op, ErrUserExists)
+	}
+
+	a.resetLoginFailures(ctx, log, user.Email)
+
+	if err := a.userSaver.AddMembership(ctx, user.ID, appID); err != nil {
+		if errors.Is(err, storage.ErrMembershipExists) {
+			log.Warn("user is already a member of the app")
+
+			return "", fmt.Errorf("%s %w", op, ErrUserExists)
+		}
+
+		log.Error("failed to add membership", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("existing user joined the app")
+
+	if !user.EmailVerified {
+		a.sendEmailVerification(ctx, log, user, appID)
+	}
+
+	return user.ID, nil
+}
+
 func (a *Auth) IsAdmin(ctx context.Context, userID string) (bool, error) {
 	const op = "services.auth.IsAdmin"
 
@@ -193,8 +664,7 @@ func (a *Auth) IsAdmin(ctx context.Context, userID string) (bool, error) {
 
 func (a *Auth) RegisterNewApp(
 	ctx context.Context,
-	name string,
-	secret string,
+	app models.App,
 ) (string, error) {
 	const op = "services.auth.RegisterNewApp"
 
@@ -205,7 +675,55 @@ func (a *Auth) RegisterNewApp(
 
 	log.Info("registering app")
 
-	id, err := a.appSaver.SaveApp(ctx, name, secret)
+	// Only tokens signed with a server key authenticate users to the SSO
+	// itself, see VerifyUserToken.
+	if app.SigningAlgorithm == "" {
+		app.SigningAlgorithm = jwt.AlgES256
+	}
+
+	if !jwt.IsSupportedAlgorithm(app.SigningAlgorithm) {
+		log.Warn("unsupported signing algorithm", slog.String("alg", app.SigningAlgorithm))
+
+		return "", fmt.Errorf("%s %w", op, ErrInvalidAlgorithm)
+	}
+
+	if err := validateRedirectURIs(app.RedirectURIs); err != nil {
+		log.Warn("invalid redirect uri")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := validateAllowedScopes(app.AllowedScopes); err != nil {
+		log.Warn("invalid allowed scope")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := validateWebAuthnRPID(app.WebAuthnRPID); err != nil {
+		log.Warn("invalid webauthn rp id")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := validateWebAuthnOrigins(app.WebAuthnRPID, app.WebAuthnOrigins); err != nil {
+		log.Warn("invalid webauthn origin")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := validatePasswordPolicy(app.PasswordPolicy); err != nil {
+		log.Warn("invalid password policy")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.protectAppSecrets(&app); err != nil {
+		log.Error("failed to protect app secret", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	id, err := a.appSaver.SaveApp(ctx, app)
 
 	if err != nil {
 
@@ -0,0 +1,415 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"sso/internal/domain/models"
+	"sso/internal/lib/oidc"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuthorizationPending = errors.New("authorization pending")
+	ErrSlowDown             = errors.New("polling too frequently")
+	ErrAccessDenied         = errors.New("access denied")
+	ErrExpiredToken         = errors.New("device code expired")
+	ErrInvalidUserCode      = errors.New("invalid user code")
+)
+
+// userCodeAlphabet has no vowels, so user codes do not spell words, and no
+// characters that are easily confused (RFC 8628, section 6.1).
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+const userCodeLength = 8
+
+// slowDownStep is added to the polling interval of a device that polls too
+// frequently (RFC 8628, section 3.5).
+const slowDownStep = 5 * time.Second
+
+// DeviceAuthorize starts the device authorization grant for the client: the
+// device shows the user code and verification URI to the user and polls the
+// token endpoint with the device code.
+func (a *Auth) DeviceAuthorize(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	scope string,
+) (models.DeviceAuthorization, error) {
+	const op = "services.auth.DeviceAuthorize"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client_id", clientID),
+	)
+
+	log.Info("starting device authorization")
+
+	app, err := a.authenticateClient(ctx, clientID, clientSecret)
+
+	if err != nil {
+		log.Warn("client authentication failed", slog.String("error:", err.Error()))
+
+		return models.DeviceAuthorization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := validateScope(scope); err != nil {
+		log.Warn("invalid scope", slog.String("scope", scope))
+
+		return models.DeviceAuthorization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	deviceCode, err := opaque.NewToken()
+
+	if err != nil {
+		return models.DeviceAuthorization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	userCode, err := newUserCode()
+
+	if err != nil {
+		return models.DeviceAuthorization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = a.deviceCodeStorage.SaveDeviceCode(ctx, models.DeviceCode{
+		DeviceCodeHash: opaque.Hash(deviceCode),
+		UserCode:       userCode,
+		AppID:          app.ID,
+		Scope:          scope,
+		Status:         models.DeviceCodePending,
+		Interval:       a.devicePollInterval,
+		ExpiresAt:      time.Now().Add(a.deviceCodeTTL),
+	})
+
+	if err != nil {
+		log.Error("failed to save device code", slog.String("error:", err.Error()))
+
+		return models.DeviceAuthorization{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("device code issued")
+
+	verificationURI := strings.TrimRight(a.issuer, "/") + oidc.DeviceVerificationPath
+	formatted := formatUserCode(userCode)
+
+	return models.DeviceAuthorization{
+		DeviceCode:              deviceCode,
+		UserCode:                formatted,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(formatted),
+		ExpiresIn:               a.deviceCodeTTL,
+		Interval:                a.devicePollInterval,
+	}, nil
+}
+
+// DeviceClient returns the app that requested the pending device
+// authorization, so the user can see what they are approving.
+func (a *Auth) DeviceClient(ctx context.Context, userCode string) (models.App, error) {
+	const op = "services.auth.DeviceClient"
+
+	code, err := a.deviceCodeStorage.DeviceCodeByUserCode(ctx, normalizeUserCode(userCode), time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+			return models.App{}, fmt.Errorf("%s %w", op, ErrInvalidUserCode)
+		}
+
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.appProvider.App(ctx, code.AppID)
+
+	if err != nil {
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return app, nil
+}
+
+// VerifyDevice approves or denies the device authorization with the given
+// user code on behalf of the owner of the access token.
+func (a *Auth) VerifyDevice(
+	ctx context.Context,
+	accessToken string,
+	userCode string,
+	approve bool,
+) error {
+	const op = "services.auth.VerifyDevice"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	claims, err := a.VerifyUserToken(ctx, accessToken)
+
+	if err != nil {
+		log.Warn("failed to verify access token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if claims.UID == "" {
+		log.Warn("access token has no user subject")
+
+		return fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	if err := a.decideDevice(ctx, log, userCode, claims.UID, approve); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// VerifyDeviceWithCredentials is VerifyDevice for the verification page,
+// where the user signs in with email, password and, with MFA enabled, a code.
+func (a *Auth) VerifyDeviceWithCredentials(
+	ctx context.Context,
+	userCode string,
+	email string,
+	password string,
+	mfaCode string,
+	approve bool,
+) error {
+	const op = "services.auth.VerifyDeviceWithCredentials"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, err := a.authenticate(ctx, log, email, password)
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMFA(ctx, log, user, mfaCode); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.decideDevice(ctx, log, userCode, user.ID, approve); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) decideDevice(
+	ctx context.Context,
+	log *slog.Logger,
+	userCode string,
+	userID string,
+	approve bool,
+) error {
+	status := models.DeviceCodeDenied
+	if approve {
+		status = models.DeviceCodeApproved
+	}
+
+	err := a.deviceCodeStorage.DecideDeviceCode(ctx, normalizeUserCode(userCode), userID, status, time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+			log.Warn("user code not found or expired")
+
+			return ErrInvalidUserCode
+		}
+
+		log.Error("failed to save device decision", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	log.Info("device authorization decided", slog.String("status", status))
+
+	return nil
+}
+
+// ExchangeDeviceCode is the device_code grant of the token endpoint. Until the
+// user decides it fails with ErrAuthorizationPending, or with ErrSlowDown when
+// the device polls faster than its interval, which is then increased.
+func (a *Auth) ExchangeDeviceCode(
+	ctx context.Context,
+	deviceCode string,
+	clientID string,
+	clientSecret string,
+) (models.Tokens, error) {
+	const op = "services.auth.ExchangeDeviceCode"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client_id", clientID),
+	)
+
+	app, err := a.authenticateClient(ctx, clientID, clientSecret)
+
+	if err != nil {
+		log.Warn("client authentication failed", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	now := time.Now()
+	hash := opaque.Hash(deviceCode)
+
+	code, err := a.deviceCodeStorage.PollDeviceCode(ctx, hash, app.ID, now)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrDeviceCodeNotFound) {
+			log.Warn("device code not found or issued for another client")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		log.Error("failed to poll device code", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if now.After(code.ExpiresAt) {
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrExpiredToken)
+	}
+
+	switch code.Status {
+	case models.DeviceCodeDenied:
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrAccessDenied)
+	case models.DeviceCodePending:
+		if code.LastPolledAt != nil && now.Sub(*code.LastPolledAt) < code.Interval {
+			if err := a.deviceCodeStorage.SlowDownDeviceCode(ctx, hash, code.Interval+slowDownStep); err != nil {
+				log.Error("failed to slow down device", slog.String("error:", err.Error()))
+			}
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrSlowDown)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrAuthorizationPending)
+	}
+
+	if code.UsedAt != nil {
+		log.Warn("device code already used")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, code.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is not a member of the app")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrAccessDenied)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrAccessDenied)
+	}
+
+	// The code is consumed only once the tokens are issued, so that a failure
+	// to issue them leaves the grant for the next poll. Tokens issued by a
+	// poll that loses the race for the code are revoked.
+	familyID := uuid.New().String()
+
+	tokens, err := a.issueTokens(ctx, user, app, familyID, "")
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.deviceCodeStorage.UseDeviceCode(ctx, hash, app.ID, time.Now()); err != nil {
+		if revokeErr := a.refreshTokenSaver.RevokeRefreshTokenFamily(ctx, familyID); revokeErr != nil {
+			log.Error("failed to revoke token family", slog.String("error:", revokeErr.Error()))
+		}
+
+		if errors.Is(err, storage.ErrDeviceCodeUsed) {
+			log.Warn("device code already used")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		log.Error("failed to use device code", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens.Scope = code.Scope
+
+	log.Info("device code exchanged")
+
+	return tokens, nil
+}
+
+// CleanupDeviceCodes drops expired device codes.
+func (a *Auth) CleanupDeviceCodes(ctx context.Context) error {
+	const op = "services.auth.CleanupDeviceCodes"
+
+	deleted, err := a.deviceCodeStorage.DeleteExpiredDeviceCodes(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("device codes cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+func newUserCode() (string, error) {
+	var b strings.Builder
+
+	alphabetSize := big.NewInt(int64(len(userCodeAlphabet)))
+
+	for i := 0; i < userCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+
+	return b.String(), nil
+}
+
+// normalizeUserCode makes user code input case-insensitive and drops
+// separators the user may have typed.
+func normalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+
+		if strings.ContainsRune(userCodeAlphabet, r) {
+			return r
+		}
+
+		return -1
+	}, userCode)
+}
+
+func formatUserCode(userCode string) string {
+	return userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
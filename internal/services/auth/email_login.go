@@ -0,0 +1,422 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"time"
+)
+
+const (
+	// maxEmailLoginAttempts is the number of wrong codes after which the
+	// code of an email login stops working. The magic link still works.
+	maxEmailLoginAttempts = 5
+	emailLoginCodeDigits  = 6
+
+	// maxEmailLoginFailures is the number of wrong codes for an email within
+	// the failure window of the lockout policy, across all its logins.
+	maxEmailLoginFailures = 10
+	// maxEmailLoginStarts is the number of email logins an email can be sent
+	// within the failure window, at least emailLoginCooldown apart.
+	maxEmailLoginStarts = 5
+	emailLoginCooldown  = time.Minute
+)
+
+var ErrInvalidEmailLoginCode = errors.New("invalid email login code")
+
+// StartEmailLogin sends a numeric code and a magic link to the email of a
+// member of the app, either of which CompleteEmailLogin exchanges for
+// tokens. Starting again replaces the code and the link sent before, but
+// only maxEmailLoginStarts times per failure window and not sooner than
+// emailLoginCooldown, unknown emails included. Unknown emails are not
+// reported, so that the RPC can not be used to find out who is registered.
+func (a *Auth) StartEmailLogin(ctx context.Context, email string, appID string, nonce string) error {
+	const op = "services.auth.StartEmailLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	app, err := a.app(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	retryAfter, err := a.loginThrottleStorage.ThrottleCall(
+		ctx,
+		emailLoginStartThrottleKey(email),
+		time.Now(),
+		a.lockoutPolicy.FailureWindow,
+		emailLoginCooldown,
+		maxEmailLoginStarts,
+	)
+
+	if err != nil {
+		log.Error("failed to count email login", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if retryAfter > 0 {
+		log.Warn("email login requested too often", slog.Duration("retry_after", retryAfter))
+
+		return fmt.Errorf("%s %w", op, &LoginThrottledError{Err: ErrTooManyAttempts, RetryAfter: retryAfter})
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("email login requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, appID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Info("email login requested by a non-member")
+
+			return nil
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	loginID, err := opaque.NewToken()
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	code, err := newEmailLoginCode()
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	key, err := a.signingKey(ctx, app)
+
+	if err != nil {
+		log.Error("failed to get signing key", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	token, err := jwt.NewEmailLoginToken(app, key, loginID, a.emailLoginTTL)
+
+	if err != nil {
+		log.Error("failed to sign email login token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	link, err := emailLoginLink(a.emailLoginURL, token)
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	now := time.Now()
+
+	err = a.emailLoginStorage.SaveEmailLogin(ctx, models.EmailLogin{
+		IDHash:    opaque.Hash(loginID),
+		UserID:    user.ID,
+		AppID:     app.ID,
+		CodeHash:  emailLoginCodeHash(user.ID, app.ID, code),
+		Nonce:     nonce,
+		ExpiresAt: now.Add(a.emailLoginTTL),
+		CreatedAt: now,
+	})
+
+	if err != nil {
+		log.Error("failed to save email login", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.notifier.SendEmailLogin(ctx, user.Email, app.ID, code, link, token); err != nil {
+		log.Error("failed to send email login", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("email login sent", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// CompleteEmailLogin completes a login started by StartEmailLogin, with the
+// token of the magic link when it is set and with the email, the app and the
+// code otherwise. Like Login, it returns the ID of an MFA challenge instead
+// of tokens for users with MFA enabled. A login can be completed once, and
+// an email gets maxEmailLoginFailures wrong codes per failure window across
+// its logins.
+func (a *Auth) CompleteEmailLogin(
+	ctx context.Context,
+	email string,
+	appID string,
+	code string,
+	token string,
+) (models.Tokens, string, error) {
+	const op = "services.auth.CompleteEmailLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	var user models.User
+	var app models.App
+	var login models.EmailLogin
+	var err error
+
+	if token != "" {
+		user, app, login, err = a.useEmailLoginLink(ctx, log, token)
+	} else {
+		user, app, login, err = a.useEmailLoginCode(ctx, log, email, appID, code)
+	}
+
+	if err != nil {
+		return models.Tokens{}, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, challengeID, err := a.completeLogin(ctx, log, user, app, login.Nonce)
+
+	if err != nil {
+		return models.Tokens{}, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return tokens, challengeID, nil
+}
+
+func (a *Auth) useEmailLoginCode(
+	ctx context.Context,
+	log *slog.Logger,
+	email string,
+	appID string,
+	code string,
+) (models.User, models.App, models.EmailLogin, error) {
+	app, err := a.app(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidAppID
+		}
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	if err := a.takeEmailLoginAttempt(ctx, log, email); err != nil {
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("email login code entered for unknown email")
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidEmailLoginCode
+		}
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	login, err := a.emailLoginStorage.UseEmailLoginCode(
+		ctx,
+		user.ID,
+		app.ID,
+		emailLoginCodeHash(user.ID, app.ID, code),
+		maxEmailLoginAttempts,
+		time.Now(),
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrEmailLoginCodeMismatch):
+			log.Info("wrong email login code", slog.String("user_id", user.ID))
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidEmailLoginCode
+		case errors.Is(err, storage.ErrEmailLoginNotFound):
+			log.Warn("no pending email login", slog.String("user_id", user.ID))
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidEmailLoginCode
+		}
+
+		log.Error("failed to use email login code", slog.String("error:", err.Error()))
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	a.resetEmailLoginFailures(ctx, log, email)
+
+	return user, app, login, nil
+}
+
+// takeEmailLoginAttempt counts a code entered for the email before it is
+// checked, so that concurrent guesses can not get past the limit, and
+// refuses it with a *LoginThrottledError after maxEmailLoginFailures codes
+// without a valid one within the failure window. Starting a new login does
+// not reset the count.
+func (a *Auth) takeEmailLoginAttempt(ctx context.Context, log *slog.Logger, email string) error {
+	window := a.lockoutPolicy.FailureWindow
+
+	throttle, err := a.loginThrottleStorage.RecordLoginFailure(ctx, emailLoginThrottleKey(email), time.Now(), window, 0, 0)
+
+	if err != nil {
+		log.Error("failed to count email login attempt", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	if throttle.Failures > maxEmailLoginFailures {
+		log.Warn("too many email login attempts")
+
+		return &LoginThrottledError{Err: ErrTooManyAttempts, RetryAfter: window}
+	}
+
+	return nil
+}
+
+// resetEmailLoginFailures forgets the codes entered for the email after a
+// login is completed.
+func (a *Auth) resetEmailLoginFailures(ctx context.Context, log *slog.Logger, email string) {
+	err := a.loginThrottleStorage.ResetLoginFailures(ctx, emailLoginThrottleKey(email))
+
+	if err != nil && !errors.Is(err, storage.ErrLoginThrottleNotFound) {
+		log.Error("failed to reset email login attempts", slog.String("error:", err.Error()))
+	}
+}
+
+func (a *Auth) useEmailLoginLink(
+	ctx context.Context,
+	log *slog.Logger,
+	token string,
+) (models.User, models.App, models.EmailLogin, error) {
+	appID, err := jwt.Audience(token)
+
+	if err != nil {
+		log.Warn("malformed email login token")
+
+		return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidToken
+	}
+
+	app, err := a.app(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidToken
+		}
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	loginID, err := jwt.ParseEmailLoginToken(token, app, func(kid string) (jwt.Key, error) {
+		return a.keyProvider.VerificationKey(ctx, kid)
+	})
+
+	if err != nil {
+		log.Warn("invalid email login token", slog.String("error:", err.Error()))
+
+		return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidToken
+	}
+
+	login, err := a.emailLoginStorage.UseEmailLoginLink(ctx, opaque.Hash(loginID), time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrEmailLoginNotFound) {
+			log.Warn("email login not found, used or expired")
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidToken
+		}
+
+		log.Error("failed to use email login link", slog.String("error:", err.Error()))
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	user, err := a.userProvider.UserByID(ctx, login.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.User{}, models.App{}, models.EmailLogin{}, ErrInvalidToken
+		}
+
+		return models.User{}, models.App{}, models.EmailLogin{}, err
+	}
+
+	a.resetEmailLoginFailures(ctx, log, user.Email)
+
+	return user, app, login, nil
+}
+
+// newEmailLoginCode returns a random numeric code, zero-padded to
+// emailLoginCodeDigits.
+func newEmailLoginCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", emailLoginCodeDigits, n), nil
+}
+
+// emailLoginCodeHash binds the code to the user and the app, so that equal
+// codes of different logins have different hashes.
+func emailLoginCodeHash(userID string, appID string, code string) string {
+	return opaque.Hash(userID + ":" + appID + ":" + code)
+}
+
+// emailLoginLink adds the token to the query of the email login page.
+func emailLoginLink(pageURL string, token string) (string, error) {
+	u, err := url.Parse(pageURL)
+
+	if err != nil {
+		return "", fmt.Errorf("invalid email login url: %w", err)
+	}
+
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (a *Auth) CleanupEmailLogins(ctx context.Context) error {
+	const op = "services.auth.CleanupEmailLogins"
+
+	deleted, err := a.emailLoginStorage.DeleteExpiredEmailLogins(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("email logins cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
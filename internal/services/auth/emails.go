@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"time"
+)
+
+var ErrInvalidEmail = errors.New("invalid email")
+
+// VerifyEmail marks the email of the user as verified with a token sent by
+// sendEmailVerification. The other pending verification tokens of the user
+// are used up.
+func (a *Auth) VerifyEmail(ctx context.Context, token string) error {
+	const op = "services.auth.VerifyEmail"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	userID, err := a.emailVerifyStorage.VerifyEmail(ctx, opaque.Hash(token), time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrEmailVerificationTokenNotFound) {
+			log.Warn("invalid email verification token")
+
+			return fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to verify email", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("email verified", slog.String("user_id", userID))
+
+	return nil
+}
+
+// RequestEmailVerification sends a new verification token to a member of the
+// app whose email is not verified yet. Like RequestPasswordReset it does not
+// reveal whether the email is registered.
+func (a *Auth) RequestEmailVerification(ctx context.Context, email string, appID string) error {
+	const op = "services.auth.RequestEmailVerification"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if _, err := a.appProvider.App(ctx, appID); err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("email verification requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if user.EmailVerified {
+		log.Info("email is already verified")
+
+		return nil
+	}
+
+	if err := a.checkMembership(ctx, user.ID, appID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Info("email verification requested by a non-member")
+
+			return nil
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.issueEmailVerification(ctx, user, appID); err != nil {
+		log.Error("failed to send email verification", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("email verification sent", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// sendEmailVerification sends a verification token to a newly registered
+// user. A failure does not fail the registration, as the user can request
+// another token with RequestEmailVerification.
+func (a *Auth) sendEmailVerification(ctx context.Context, log *slog.Logger, user models.User, appID string) {
+	if err := a.issueEmailVerification(ctx, user, appID); err != nil {
+		log.Error("failed to send email verification", slog.String("error:", err.Error()))
+
+		return
+	}
+
+	log.Info("email verification sent", slog.String("user_id", user.ID))
+}
+
+func (a *Auth) issueEmailVerification(ctx context.Context, user models.User, appID string) error {
+	token, err := opaque.NewToken()
+
+	if err != nil {
+		return err
+	}
+
+	err = a.emailVerifyStorage.SaveEmailVerificationToken(ctx, models.EmailVerificationToken{
+		TokenHash: opaque.Hash(token),
+		UserID:    user.ID,
+		AppID:     appID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(a.emailVerificationTTL),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return a.notifier.SendEmailVerification(ctx, user.Email, appID, token)
+}
+
+func (a *Auth) CleanupEmailVerificationTokens(ctx context.Context) error {
+	const op = "services.auth.CleanupEmailVerificationTokens"
+
+	deleted, err := a.emailVerifyStorage.DeleteExpiredEmailVerificationTokens(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("email verification tokens cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+// checkEmailVerified returns ErrEmailNotVerified when the app requires a
+// verified email and the email of the user is not verified.
+func checkEmailVerified(user models.User, app models.App) error {
+	if app.RequireEmailVerification && !user.EmailVerified {
+		return ErrEmailNotVerified
+	}
+
+	return nil
+}
+
+// validateEmail accepts a bare address such as user@example.com, without a
+// display name.
+func validateEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+
+	if err != nil || address.Address != email {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/clientip"
+	"sso/internal/storage"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTooManyAttempts = errors.New("too many login attempts")
+	ErrAccountLocked   = errors.New("account is locked")
+)
+
+// LockoutPolicy slows down password guessing. Failed logins are counted per
+// account and per client address. After BackoffAfter failures every further
+// attempt has to wait BackoffBase, doubled with each failure up to
+// BackoffMax, and LockoutThreshold failures lock the account for
+// LockoutDuration. Addresses are never locked, and are only delayed after
+// IPBackoffAfter failures, as many users can share one.
+type LockoutPolicy struct {
+	// FailureWindow is how long a failure is remembered after the last one.
+	FailureWindow    time.Duration
+	BackoffAfter     int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	// IPBackoffAfter is the BackoffAfter of client addresses. 0 turns the
+	// throttling of addresses off.
+	IPBackoffAfter int
+}
+
+// LoginThrottledError is returned for logins refused before the password is
+// checked. It wraps ErrTooManyAttempts or ErrAccountLocked.
+type LoginThrottledError struct {
+	Err error
+	// RetryAfter is how long until the next attempt is accepted.
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// UnlockUser lifts the lockout of the user and forgets their failed logins
+// and MFA codes.
+func (a *Auth) UnlockUser(ctx context.Context, userID string) error {
+	const op = "services.auth.UnlockUser"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	user, err := a.userProvider.UserByID(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return fmt.Errorf("%s %w", op, ErrUserNotFound)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = a.loginThrottleStorage.ResetLoginFailures(ctx, accountThrottleKey(user.Email))
+
+	if err != nil && !errors.Is(err, storage.ErrLoginThrottleNotFound) {
+		log.Error("failed to reset login failures", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.resetMFAFailures(ctx, log, user)
+
+	log.Info("user unlocked")
+
+	return nil
+}
+
+// checkLoginThrottle refuses the login with a *LoginThrottledError while the
+// account is locked or the account or the client address has to wait.
+func (a *Auth) checkLoginThrottle(ctx context.Context, log *slog.Logger, email string) error {
+	keys := []string{accountThrottleKey(email)}
+	ip := clientip.IP(ctx)
+
+	if ip != "" && a.lockoutPolicy.IPBackoffAfter > 0 {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+
+	throttles, err := a.loginThrottleStorage.LoginThrottles(ctx, keys)
+
+	if err != nil {
+		log.Error("failed to get login throttles", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	now := time.Now()
+
+	var retryAfter time.Duration
+
+	for _, throttle := range throttles {
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			log.Warn("login to a locked account")
+
+			return &LoginThrottledError{Err: ErrAccountLocked, RetryAfter: throttle.LockedUntil.Sub(now)}
+		}
+
+		backoffAfter := a.lockoutPolicy.BackoffAfter
+		if strings.HasPrefix(throttle.Key, ipThrottlePrefix) {
+			backoffAfter = a.lockoutPolicy.IPBackoffAfter
+		}
+
+		wait := throttle.LastFailureAt.Add(a.loginBackoff(throttle, backoffAfter, now)).Sub(now)
+		retryAfter = max(retryAfter, wait)
+	}
+
+	if retryAfter > 0 {
+		log.Warn("login attempted too soon after failures", slog.Duration("retry_after", retryAfter))
+
+		return &LoginThrottledError{Err: ErrTooManyAttempts, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// loginBackoff returns how long after its last failure the throttle accepts
+// the next attempt.
+func (a *Auth) loginBackoff(throttle models.LoginThrottle, backoffAfter int, now time.Time) time.Duration {
+	if throttle.LastFailureAt.Before(now.Add(-a.lockoutPolicy.FailureWindow)) {
+		return 0
+	}
+
+	excess := throttle.Failures - backoffAfter
+
+	if backoffAfter <= 0 || excess < 0 {
+		return 0
+	}
+
+	backoff := a.lockoutPolicy.BackoffBase
+
+	for range excess {
+		if backoff >= a.lockoutPolicy.BackoffMax {
+			break
+		}
+
+		backoff *= 2
+	}
+
+	return min(backoff, a.lockoutPolicy.BackoffMax)
+}
+
+// recordLoginFailure counts a failed login for the account and the client
+// address. The login fails anyway, so errors are only logged.
+func (a *Auth) recordLoginFailure(ctx context.Context, log *slog.Logger, email string) {
+	now := time.Now()
+	policy := a.lockoutPolicy
+
+	throttle, err := a.loginThrottleStorage.RecordLoginFailure(
+		ctx,
+		accountThrottleKey(email),
+		now,
+		policy.FailureWindow,
+		policy.LockoutThreshold,
+		policy.LockoutDuration,
+	)
+
+	if err != nil {
+		log.Error("failed to record login failure", slog.String("error:", err.Error()))
+	} else if throttle.LockedUntil != nil && !throttle.LockedUntil.Before(now) {
+		log.Warn("account locked", slog.Int("failures", throttle.Failures))
+	}
+
+	if ip := clientip.IP(ctx); ip != "" && policy.IPBackoffAfter > 0 {
+		_, err := a.loginThrottleStorage.RecordLoginFailure(ctx, ipThrottleKey(ip), now, policy.FailureWindow, 0, 0)
+
+		if err != nil {
+			log.Error("failed to record login failure", slog.String("error:", err.Error()))
+		}
+	}
+}
+
+// resetLoginFailures forgets the failed logins of the account after a
+// successful one. Those of the client address are kept, so that guessing the
+// passwords of many accounts stays slow for an attacker who knows one.
+func (a *Auth) resetLoginFailures(ctx context.Context, log *slog.Logger, email string) {
+	err := a.loginThrottleStorage.ResetLoginFailures(ctx, accountThrottleKey(email))
+
+	if err != nil && !errors.Is(err, storage.ErrLoginThrottleNotFound) {
+		log.Error("failed to reset login failures", slog.String("error:", err.Error()))
+	}
+}
+
+const (
+	accountThrottlePrefix = "account:"
+	ipThrottlePrefix      = "ip:"
+	mfaThrottlePrefix     = "mfa:"
+
+	emailLoginThrottlePrefix      = "email_login:"
+	emailLoginStartThrottlePrefix = "email_login_start:"
+)
+
+func accountThrottleKey(email string) string {
+	return accountThrottlePrefix + strings.ToLower(email)
+}
+
+func ipThrottleKey(ip string) string {
+	return ipThrottlePrefix + ip
+}
+
+func mfaThrottleKey(userID string) string {
+	return mfaThrottlePrefix + userID
+}
+
+func emailLoginThrottleKey(email string) string {
+	return emailLoginThrottlePrefix + strings.ToLower(email)
+}
+
+func emailLoginStartThrottleKey(email string) string {
+	return emailLoginStartThrottlePrefix + strings.ToLower(email)
+}
+
+func (a *Auth) CleanupLoginThrottles(ctx context.Context) error {
+	const op = "services.auth.CleanupLoginThrottles"
+
+	deleted, err := a.loginThrottleStorage.DeleteStaleLoginThrottles(ctx, time.Now().Add(-a.lockoutPolicy.FailureWindow))
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("login throttles cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
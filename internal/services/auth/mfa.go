@@ -0,0 +1,398 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/lib/totp"
+	"sso/internal/storage"
+	"time"
+)
+
+// maxMFAAttempts is the number of codes after which a challenge can no
+// longer be completed and the user has to log in again.
+const maxMFAAttempts = 5
+
+// maxMFAFailures is the number of wrong codes a user can enter within the
+// failure window of the lockout policy, across all challenges and flows.
+const maxMFAFailures = 10
+
+var (
+	ErrMFARequired       = errors.New("mfa code is required")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+	ErrMFAAlreadyEnabled = errors.New("mfa is already enabled")
+	ErrMFANotEnrolled    = errors.New("mfa enrollment is not started")
+	ErrMFANotEnabled     = errors.New("mfa is not enabled")
+)
+
+// EnrollTOTP generates a TOTP secret for the owner of the access token and
+// returns it together with its otpauth:// URI and a new set of recovery
+// codes. The secret takes effect once it is confirmed with ConfirmTOTP;
+// enrolling again before that replaces it.
+func (a *Auth) EnrollTOTP(ctx context.Context, accessToken string) (string, string, []string, error) {
+	const op = "services.auth.EnrollTOTP"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, _, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	if user.TOTPEnabled {
+		log.Warn("totp is already enabled", slog.String("user_id", user.ID))
+
+		return "", "", nil, fmt.Errorf("%s %w", op, ErrMFAAlreadyEnabled)
+	}
+
+	secret, err := totp.NewSecret()
+
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	sealed, err := a.sealer.Seal([]byte(secret))
+
+	if err != nil {
+		log.Error("failed to encrypt totp secret", slog.String("error:", err.Error()))
+
+		return "", "", nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.mfaStorage.SaveTOTPSecret(ctx, user.ID, sealed); err != nil {
+		if errors.Is(err, storage.ErrTOTPEnabled) {
+			return "", "", nil, fmt.Errorf("%s %w", op, ErrMFAAlreadyEnabled)
+		}
+
+		log.Error("failed to save totp secret", slog.String("error:", err.Error()))
+
+		return "", "", nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	recoveryCodes, err := a.issueRecoveryCodes(ctx, user.ID)
+
+	if err != nil {
+		log.Error("failed to save recovery codes", slog.String("error:", err.Error()))
+
+		return "", "", nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("totp enrollment started", slog.String("user_id", user.ID))
+
+	return secret, totp.URI(a.totpIssuer, user.Email, secret), recoveryCodes, nil
+}
+
+// ConfirmTOTP enables MFA for the owner of the access token once the user
+// proves with a code that the authenticator app holds the enrolled secret.
+func (a *Auth) ConfirmTOTP(ctx context.Context, accessToken string, code string) error {
+	const op = "services.auth.ConfirmTOTP"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, _, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if user.TOTPEnabled {
+		log.Warn("totp is already enabled", slog.String("user_id", user.ID))
+
+		return fmt.Errorf("%s %w", op, ErrMFAAlreadyEnabled)
+	}
+
+	if user.TOTPSecretCiphertext == "" {
+		log.Warn("totp enrollment is not started", slog.String("user_id", user.ID))
+
+		return fmt.Errorf("%s %w", op, ErrMFANotEnrolled)
+	}
+
+	step, err := a.matchTOTP(user, code)
+
+	if err != nil {
+		log.Info("invalid totp code", slog.String("user_id", user.ID))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.mfaStorage.EnableTOTP(ctx, user.ID, step); err != nil {
+		if errors.Is(err, storage.ErrTOTPEnabled) {
+			return fmt.Errorf("%s %w", op, ErrMFAAlreadyEnabled)
+		}
+
+		log.Error("failed to enable totp", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("totp enabled", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// VerifyMFA completes a login started by Login with a code from the
+// authenticator app or a recovery code, and returns the number of recovery
+// codes left. A challenge can be completed once and accepts a few wrong
+// codes before it has to be started over. Wrong codes also count as failed
+// logins of the user, see LockoutPolicy.
+func (a *Auth) VerifyMFA(ctx context.Context, challengeID string, code string) (models.Tokens, int, error) {
+	const op = "services.auth.VerifyMFA"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	idHash := opaque.Hash(challengeID)
+
+	challenge, err := a.mfaStorage.MFAChallenge(ctx, idHash, time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrMFAChallengeNotFound) {
+			log.Warn("mfa challenge not found or expired")
+
+			return models.Tokens{}, 0, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to get mfa challenge", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, challenge.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, 0, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkLoginThrottle(ctx, log, user.Email); err != nil {
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.mfaStorage.TakeMFAChallengeAttempt(ctx, idHash, maxMFAAttempts); err != nil {
+		if errors.Is(err, storage.ErrMFAChallengeNotFound) {
+			log.Warn("mfa challenge has no attempts left", slog.String("user_id", challenge.UserID))
+
+			return models.Tokens{}, 0, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to count mfa attempt", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.verifyMFACode(ctx, log, user, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			a.recordLoginFailure(ctx, log, user.Email)
+		}
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.mfaStorage.UseMFAChallenge(ctx, idHash, time.Now()); err != nil {
+		if errors.Is(err, storage.ErrMFAChallengeNotFound) {
+			log.Warn("mfa challenge already used")
+
+			return models.Tokens{}, 0, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.app(ctx, challenge.AppID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, 0, fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, err := a.issueTokens(ctx, user, app, "", challenge.Nonce)
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	remaining, err := a.mfaStorage.RecoveryCodesRemaining(ctx, user.ID)
+
+	if err != nil {
+		log.Error("failed to count recovery codes", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("user logged in succesfully", slog.String("user_id", user.ID))
+
+	return tokens, remaining, nil
+}
+
+// startMFAChallenge saves the second step of a login and returns the
+// challenge ID to complete it with.
+func (a *Auth) startMFAChallenge(ctx context.Context, user models.User, app models.App, nonce string) (string, error) {
+	challengeID, err := opaque.NewToken()
+
+	if err != nil {
+		return "", err
+	}
+
+	err = a.mfaStorage.SaveMFAChallenge(ctx, models.MFAChallenge{
+		IDHash:    opaque.Hash(challengeID),
+		UserID:    user.ID,
+		AppID:     app.ID,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().Add(a.mfaChallengeTTL),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return challengeID, nil
+}
+
+// checkMFA is the second factor of the flows that take the code together
+// with the password, like the authorization and device verification pages.
+// Users without MFA pass, the others need a valid code.
+func (a *Auth) checkMFA(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	if !user.TOTPEnabled {
+		return nil
+	}
+
+	if code == "" {
+		log.Info("mfa code is required", slog.String("user_id", user.ID))
+
+		return ErrMFARequired
+	}
+
+	return a.verifyMFACode(ctx, log, user, code)
+}
+
+// verifyMFACode checks a code from the authenticator app or a recovery code
+// of a user with MFA enabled. Each code is accepted once, and the user gets
+// maxMFAFailures wrong codes, see takeMFAAttempt.
+func (a *Auth) verifyMFACode(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	if err := a.takeMFAAttempt(ctx, log, user); err != nil {
+		return err
+	}
+
+	if err := a.matchMFACode(ctx, log, user, code); err != nil {
+		return err
+	}
+
+	a.resetMFAFailures(ctx, log, user)
+
+	return nil
+}
+
+// takeMFAAttempt counts a code entered by the user before it is checked, so
+// that concurrent guesses can not get past the limit, and refuses it with a
+// *LoginThrottledError after maxMFAFailures codes without a valid one within
+// the failure window. Unlike failed logins, the count is not reset by the
+// right password.
+func (a *Auth) takeMFAAttempt(ctx context.Context, log *slog.Logger, user models.User) error {
+	window := a.lockoutPolicy.FailureWindow
+
+	throttle, err := a.loginThrottleStorage.RecordLoginFailure(ctx, mfaThrottleKey(user.ID), time.Now(), window, 0, 0)
+
+	if err != nil {
+		log.Error("failed to count mfa attempt", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	if throttle.Failures > maxMFAFailures {
+		log.Warn("too many mfa attempts", slog.String("user_id", user.ID))
+
+		return &LoginThrottledError{Err: ErrTooManyAttempts, RetryAfter: window}
+	}
+
+	return nil
+}
+
+// resetMFAFailures forgets the codes the user entered after a valid one.
+func (a *Auth) resetMFAFailures(ctx context.Context, log *slog.Logger, user models.User) {
+	err := a.loginThrottleStorage.ResetLoginFailures(ctx, mfaThrottleKey(user.ID))
+
+	if err != nil && !errors.Is(err, storage.ErrLoginThrottleNotFound) {
+		log.Error("failed to reset mfa attempts", slog.String("error:", err.Error()))
+	}
+}
+
+// matchMFACode checks the code without counting the attempt.
+func (a *Auth) matchMFACode(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	if len(code) != totp.Digits {
+		return a.useRecoveryCode(ctx, log, user, code)
+	}
+
+	step, err := a.matchTOTP(user, code)
+
+	if err != nil {
+		log.Info("invalid totp code", slog.String("user_id", user.ID))
+
+		return err
+	}
+
+	if err := a.mfaStorage.UseTOTPStep(ctx, user.ID, step); err != nil {
+		if errors.Is(err, storage.ErrTOTPCodeUsed) {
+			log.Warn("totp code replayed", slog.String("user_id", user.ID))
+
+			return ErrInvalidMFACode
+		}
+
+		log.Error("failed to save totp step", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	return nil
+}
+
+// matchTOTP returns the time step the code is valid for.
+func (a *Auth) matchTOTP(user models.User, code string) (int64, error) {
+	secret, err := a.sealer.Open(user.TOTPSecretCiphertext)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt totp secret of user %s: %w", user.ID, err)
+	}
+
+	step, ok := totp.Validate(string(secret), code, time.Now())
+
+	if !ok {
+		return 0, ErrInvalidMFACode
+	}
+
+	return step, nil
+}
+
+func (a *Auth) CleanupMFAChallenges(ctx context.Context) error {
+	const op = "services.auth.CleanupMFAChallenges"
+
+	deleted, err := a.mfaStorage.DeleteExpiredMFAChallenges(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("mfa challenges cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
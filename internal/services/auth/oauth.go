@@ -0,0 +1,485 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/oidc"
+	"sso/internal/lib/opaque"
+	"sso/internal/lib/pkce"
+	"sso/internal/storage"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidClient      = errors.New("invalid client")
+	ErrInvalidRedirectURI = errors.New("invalid redirect uri")
+	ErrInvalidScope       = errors.New("invalid scope")
+	ErrInvalidGrant       = errors.New("invalid grant")
+	ErrInvalidPKCE        = errors.New("invalid code challenge")
+)
+
+// AuthorizeClient checks that the client exists and the redirect URI is one
+// of the URIs registered for it. Only after this check errors may be
+// reported to the client by redirecting to the URI.
+func (a *Auth) AuthorizeClient(
+	ctx context.Context,
+	clientID string,
+	redirectURI string,
+) (models.App, error) {
+	const op = "services.auth.AuthorizeClient"
+
+	app, err := a.appProvider.App(ctx, clientID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return models.App{}, fmt.Errorf("%s %w", op, ErrInvalidClient)
+		}
+
+		return models.App{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if !slices.Contains(app.RedirectURIs, redirectURI) {
+		return models.App{}, fmt.Errorf("%s %w", op, ErrInvalidRedirectURI)
+	}
+
+	return app, nil
+}
+
+// Authorize authenticates the user and issues a short-lived single-use
+// authorization code bound to the client, redirect URI, scope and PKCE code
+// challenge of the request.
+func (a *Auth) Authorize(
+	ctx context.Context,
+	req models.AuthorizationRequest,
+	email string,
+	password string,
+	mfaCode string,
+) (string, error) {
+	const op = "services.auth.Authorize"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client_id", req.ClientID),
+	)
+
+	log.Info("authorizing user")
+
+	app, err := a.AuthorizeClient(ctx, req.ClientID, req.RedirectURI)
+
+	if err != nil {
+		log.Warn("client authorization failed", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if req.CodeChallengeMethod != pkce.MethodS256 || req.CodeChallenge == "" {
+		log.Warn("unsupported code challenge", slog.String("method", req.CodeChallengeMethod))
+
+		return "", fmt.Errorf("%s %w", op, ErrInvalidPKCE)
+	}
+
+	if err := validateScope(req.Scope); err != nil {
+		log.Warn("invalid scope", slog.String("scope", req.Scope))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.authenticate(ctx, log, email, password)
+
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is not a member of the app")
+
+			return "", fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		}
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMFA(ctx, log, user, mfaCode); err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	code, err := opaque.NewToken()
+
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	err = a.authCodeStorage.SaveAuthorizationCode(ctx, models.AuthorizationCode{
+		CodeHash:            opaque.Hash(code),
+		AppID:               app.ID,
+		UserID:              user.ID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		FamilyID:            uuid.New().String(),
+		ExpiresAt:           time.Now().Add(a.authCodeTTL),
+	})
+
+	if err != nil {
+		log.Error("failed to save authorization code", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("authorization code issued")
+
+	return code, nil
+}
+
+// ExchangeAuthorizationCode redeems an authorization code for tokens. The
+// code is only consumed once the client, redirect URI and code verifier have
+// been checked, so a failed attempt does not burn it. A code presented twice
+// revokes the tokens issued for its first redemption.
+func (a *Auth) ExchangeAuthorizationCode(
+	ctx context.Context,
+	code string,
+	clientID string,
+	clientSecret string,
+	redirectURI string,
+	codeVerifier string,
+) (models.Tokens, error) {
+	const op = "services.auth.ExchangeAuthorizationCode"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client_id", clientID),
+	)
+
+	log.Info("exchanging authorization code")
+
+	app, err := a.authenticateClient(ctx, clientID, clientSecret)
+
+	if err != nil {
+		log.Warn("client authentication failed", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	codeHash := opaque.Hash(code)
+
+	stored, err := a.authCodeStorage.AuthorizationCode(ctx, codeHash)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAuthorizationCodeNotFound) {
+			log.Warn("authorization code not found")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		log.Error("failed to get authorization code", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if stored.AppID != app.ID || stored.RedirectURI != redirectURI {
+		log.Warn("authorization code is issued for another client")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+	}
+
+	if stored.UsedAt != nil {
+		return models.Tokens{}, fmt.Errorf("%s %w", op, a.revokeReusedCode(ctx, log, stored))
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		log.Warn("authorization code is expired")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+	}
+
+	if !pkce.Verify(codeVerifier, stored.CodeChallenge) {
+		log.Warn("code verifier does not match")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, stored.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidGrant)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	accessTokenID := uuid.New().String()
+
+	if err := a.authCodeStorage.UseAuthorizationCode(ctx, codeHash, app.ID, accessTokenID, time.Now()); err != nil {
+		if errors.Is(err, storage.ErrAuthorizationCodeUsed) {
+			// A concurrent request used the code after it was loaded; reload it
+			// to get the id of the access token that request issued.
+			if stored, err = a.authCodeStorage.AuthorizationCode(ctx, codeHash); err != nil {
+				log.Error("failed to get authorization code", slog.String("error:", err.Error()))
+
+				return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+			}
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, a.revokeReusedCode(ctx, log, stored))
+		}
+
+		log.Error("failed to use authorization code", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, err := a.issueTokensWithID(ctx, user, app, accessTokenID, stored.FamilyID, stored.Nonce)
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens.Scope = stored.Scope
+
+	log.Info("authorization code exchanged")
+
+	return tokens, nil
+}
+
+// revokeReusedCode revokes the tokens issued for the first redemption of an
+// authorization code that was presented again, and returns ErrInvalidGrant.
+// The access token goes to the denylist, its refresh token family is revoked.
+func (a *Auth) revokeReusedCode(ctx context.Context, log *slog.Logger, code models.AuthorizationCode) error {
+	log.Warn("authorization code reuse detected, revoking tokens")
+
+	if err := a.refreshTokenSaver.RevokeRefreshTokenFamily(ctx, code.FamilyID); err != nil {
+		log.Error("failed to revoke token family", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	// Codes used before access token ids were recorded have none.
+	if code.AccessTokenID != "" {
+		// The token was issued after the code was used, so it expires within
+		// tokenTTL from now.
+		if err := a.tokenRevoker.RevokeToken(ctx, code.AccessTokenID, time.Now().Add(a.tokenTTL)); err != nil {
+			log.Error("failed to revoke access token", slog.String("error:", err.Error()))
+
+			return err
+		}
+	}
+
+	return ErrInvalidGrant
+}
+
+// ExchangeRefreshToken is the refresh_token grant of the token endpoint. Unlike
+// Refresh it authenticates confidential clients with their secret.
+func (a *Auth) ExchangeRefreshToken(
+	ctx context.Context,
+	refreshToken string,
+	clientID string,
+	clientSecret string,
+) (models.Tokens, error) {
+	const op = "services.auth.ExchangeRefreshToken"
+
+	if _, err := a.authenticateClient(ctx, clientID, clientSecret); err != nil {
+		a.log.Warn("client authentication failed", slog.String("op", op), slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, err := a.Refresh(ctx, refreshToken, clientID)
+
+	if err != nil {
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return tokens, nil
+}
+
+// ClientCredentials authenticates an app by its id and secret and issues an
+// access token for the app itself. The requested scope must be a subset of
+// the scopes allowed for the app; an empty scope grants all of them. No
+// refresh token is issued, the app simply requests a new token.
+func (a *Auth) ClientCredentials(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	scope string,
+) (models.Tokens, error) {
+	const op = "services.auth.ClientCredentials"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client_id", clientID),
+	)
+
+	log.Info("issuing client token")
+
+	app, err := a.authenticateClient(ctx, clientID, clientSecret)
+
+	if err != nil {
+		log.Warn("client authentication failed", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if app.PublicClient {
+		log.Warn("public client can not use client credentials")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidClient)
+	}
+
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 {
+		scopes = app.AllowedScopes
+	}
+
+	for _, s := range scopes {
+		if !slices.Contains(app.AllowedScopes, s) {
+			log.Warn("scope is not allowed", slog.String("scope", s))
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidScope)
+		}
+	}
+
+	scope = strings.Join(scopes, " ")
+
+	key, err := a.signingKey(ctx, app)
+
+	if err != nil {
+		log.Error("failed to get signing key", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	accessToken, err := jwt.NewClientToken(app, key, scope, a.tokenTTL)
+
+	if err != nil {
+		log.Error("failed to generate token", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("client token issued")
+
+	return models.Tokens{
+		AccessToken: accessToken,
+		Scope:       scope,
+		ExpiresIn:   a.tokenTTL,
+	}, nil
+}
+
+// CleanupAuthorizationCodes drops expired authorization codes.
+func (a *Auth) CleanupAuthorizationCodes(ctx context.Context) error {
+	const op = "services.auth.CleanupAuthorizationCodes"
+
+	deleted, err := a.authCodeStorage.DeleteExpiredAuthorizationCodes(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("authorization codes cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+// authenticateClient looks up the client and checks the secret of a
+// confidential client. Public clients can not keep a secret and are not
+// authenticated.
+func (a *Auth) authenticateClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+) (models.App, error) {
+	app, err := a.app(ctx, clientID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return models.App{}, ErrInvalidClient
+		}
+
+		return models.App{}, err
+	}
+
+	if app.PublicClient {
+		return app, nil
+	}
+
+	if !checkSecret(app.SecretHashes(time.Now()), clientSecret) {
+		return models.App{}, ErrInvalidClient
+	}
+
+	return app, nil
+}
+
+func validateScope(scope string) error {
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(oidc.Scopes, s) {
+			return ErrInvalidScope
+		}
+	}
+
+	return nil
+}
+
+// isValidScopeToken reports whether s is a scope-token of RFC 6749,
+// section 3.3: printable ASCII except space, double quote and backslash.
+func isValidScopeToken(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < 0x21 || c > 0x7e || c == '"' || c == '\\' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidRedirectURI accepts absolute URIs without a fragment (RFC 6749,
+// section 3.1.2). Custom schemes of native apps have no host.
+func isValidRedirectURI(redirectURI string) bool {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		return false
+	}
+
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return u.Host != ""
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"sso/internal/domain/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+func normalizePageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultPageSize
+	}
+
+	return min(pageSize, maxPageSize)
+}
+
+// encodePageToken makes an opaque page token of the position after the last
+// entry of a page.
+func encodePageToken(cursor models.Cursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + ":" + cursor.ID
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken returns the position a page starts after, or nil for the
+// first page.
+func decodePageToken(token string) (*models.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, ErrInvalidPageToken
+	}
+
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &models.Cursor{CreatedAt: time.Unix(0, createdAt), ID: id}, nil
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sso/internal/domain/models"
+	"sso/internal/lib/password"
+	"sso/internal/storage"
+	"strings"
+)
+
+var (
+	ErrWeakPassword          = errors.New("password does not satisfy the policy")
+	ErrInvalidPasswordPolicy = errors.New("invalid password policy")
+)
+
+// PasswordPolicyError is returned for passwords that break the password
+// policy of the app. It wraps ErrWeakPassword.
+type PasswordPolicyError struct {
+	Violations []password.Violation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error()
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// describePolicyError adds the violated rules to a *PasswordPolicyError, for
+// errors read by operators rather than clients.
+func describePolicyError(err error) error {
+	var policyErr *PasswordPolicyError
+
+	if !errors.As(err, &policyErr) {
+		return err
+	}
+
+	descriptions := make([]string, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		descriptions = append(descriptions, violation.Description)
+	}
+
+	return fmt.Errorf("%w: %s", err, strings.Join(descriptions, "; "))
+}
+
+// passwordPolicy returns the policy of the app, or the default policy when
+// the app does not override it.
+func (a *Auth) passwordPolicy(app models.App) models.PasswordPolicy {
+	if app.PasswordPolicy != nil {
+		return *app.PasswordPolicy
+	}
+
+	return a.defaultPasswordPolicy
+}
+
+// checkPassword returns a *PasswordPolicyError when the new password of the
+// user with the email breaks the policy.
+func checkPassword(log *slog.Logger, policy models.PasswordPolicy, newPassword string, email string) error {
+	violations := password.Check(policy, newPassword, email)
+
+	if len(violations) > 0 {
+		log.Info("password does not satisfy the policy", slog.Int("violations", len(violations)))
+
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+// checkUserPassword is checkPassword for a user that may be a member of
+// several apps: the new password is shared by all of them, so it must
+// satisfy the strictest combination of their policies. appID, the app the
+// change is made from, counts even if the membership is gone. Apps that do
+// not exist anymore are skipped; without any app the default policy applies.
+func (a *Auth) checkUserPassword(
+	ctx context.Context,
+	log *slog.Logger,
+	user models.User,
+	appID string,
+	newPassword string,
+) error {
+	userApps, err := a.userProvider.UserApps(ctx, []string{user.ID})
+
+	if err != nil {
+		log.Error("failed to get apps of the user", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	appIDs := userApps[user.ID]
+	if !slices.Contains(appIDs, appID) {
+		appIDs = append(appIDs, appID)
+	}
+
+	var policies []models.PasswordPolicy
+
+	for _, id := range appIDs {
+		app, err := a.appProvider.App(ctx, id)
+
+		if err != nil {
+			if errors.Is(err, storage.ErrAppNotFound) {
+				continue
+			}
+
+			log.Error("failed to get app", slog.String("error:", err.Error()))
+
+			return err
+		}
+
+		policies = append(policies, a.passwordPolicy(app))
+	}
+
+	if len(policies) == 0 {
+		policies = append(policies, a.defaultPasswordPolicy)
+	}
+
+	return checkPassword(log, strictestPasswordPolicy(policies), newPassword, user.Email)
+}
+
+// strictestPasswordPolicy combines the policies into one that a password
+// satisfies only if it satisfies each of them.
+func strictestPasswordPolicy(policies []models.PasswordPolicy) models.PasswordPolicy {
+	var strictest models.PasswordPolicy
+
+	for _, policy := range policies {
+		strictest.MinLength = max(strictest.MinLength, policy.MinLength)
+		strictest.MinCharClasses = max(strictest.MinCharClasses, policy.MinCharClasses)
+		strictest.MinStrength = max(strictest.MinStrength, policy.MinStrength)
+		strictest.DisallowEmail = strictest.DisallowEmail || policy.DisallowEmail
+
+		if policy.MaxLength > 0 && (strictest.MaxLength == 0 || policy.MaxLength < strictest.MaxLength) {
+			strictest.MaxLength = policy.MaxLength
+		}
+	}
+
+	return strictest
+}
+
+func validatePasswordPolicy(policy *models.PasswordPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	switch {
+	case policy.MinLength < 0,
+		policy.MaxLength < 0,
+		policy.MaxLength > 0 && policy.MaxLength < policy.MinLength,
+		policy.MinCharClasses < 0 || policy.MinCharClasses > 4,
+		policy.MinStrength < 0 || policy.MinStrength > password.MaxStrength:
+		return ErrInvalidPasswordPolicy
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ChangePassword replaces the password of the owner of the access token. The
+// current password must match, so a stolen token alone can not take over the
+// account. Every session of the user ends, the current one included: the
+// refresh tokens are revoked and the access tokens stop being accepted.
+func (a *Auth) ChangePassword(
+	ctx context.Context,
+	accessToken string,
+	currentPassword string,
+	newPassword string,
+) error {
+	const op = "services.auth.ChangePassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, claims, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.Passhash, []byte(currentPassword)); err != nil {
+		log.Info("invalid current password")
+
+		return fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+	}
+
+	if err := a.checkUserPassword(ctx, log, user, claims.AppID, newPassword); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+
+	if err != nil {
+		log.Error("failed to generate password hash", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.userSaver.UpdatePassword(ctx, user.ID, passHash); err != nil {
+		log.Error("failed to update password", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("password changed", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// RequestPasswordReset sends a password reset token to the user with the
+// email through the notifier. Unknown emails and users that are not members
+// of the app are silently ignored, so the result does not reveal whether an
+// email is registered.
+func (a *Auth) RequestPasswordReset(ctx context.Context, email string, appID string) error {
+	const op = "services.auth.RequestPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	if _, err := a.appProvider.App(ctx, appID); err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.User(ctx, email)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("password reset requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, appID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Info("password reset requested by a non-member")
+
+			return nil
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	token, err := opaque.NewToken()
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	err = a.passwordResetStorage.SavePasswordResetToken(ctx, models.PasswordResetToken{
+		TokenHash: opaque.Hash(token),
+		UserID:    user.ID,
+		AppID:     appID,
+		ExpiresAt: time.Now().Add(a.passwordResetTTL),
+	})
+
+	if err != nil {
+		log.Error("failed to save password reset token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.notifier.SendPasswordReset(ctx, user.Email, appID, token); err != nil {
+		log.Error("failed to send password reset", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("password reset sent", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// ConfirmPasswordReset sets a new password with a reset token issued by
+// RequestPasswordReset. The token and the other pending reset tokens of the
+// user are used up and, like with ChangePassword, every session of the user
+// ends.
+func (a *Auth) ConfirmPasswordReset(ctx context.Context, token string, newPassword string) error {
+	const op = "services.auth.ConfirmPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	resetToken, err := a.passwordResetStorage.PasswordResetToken(ctx, opaque.Hash(token), time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrPasswordResetTokenNotFound) {
+			log.Warn("invalid password reset token")
+
+			return fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to get password reset token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, resetToken.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("invalid password reset token")
+
+			return fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkUserPassword(ctx, log, user, resetToken.AppID, newPassword); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+
+	if err != nil {
+		log.Error("failed to generate password hash", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	userID, err := a.passwordResetStorage.ResetPassword(ctx, opaque.Hash(token), passHash, time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrPasswordResetTokenNotFound) || errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("invalid password reset token")
+
+			return fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to reset password", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("password reset", slog.String("user_id", userID))
+
+	return nil
+}
+
+func (a *Auth) CleanupPasswordResetTokens(ctx context.Context) error {
+	const op = "services.auth.CleanupPasswordResetTokens"
+
+	deleted, err := a.passwordResetStorage.DeleteExpiredPasswordResetTokens(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("password reset tokens cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
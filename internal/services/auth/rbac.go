@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/storage"
+)
+
+var (
+	ErrRoleExists        = errors.New("role already exists")
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrInvalidRoleName   = errors.New("invalid role name")
+	ErrInvalidPermission = errors.New("invalid permission")
+	ErrPermissionDenied  = errors.New("not allowed to check permissions of the user in the app")
+)
+
+// CreateRole defines a role of the app with the given permissions.
+func (a *Auth) CreateRole(
+	ctx context.Context,
+	appID string,
+	name string,
+	permissions []string,
+) (string, error) {
+	const op = "services.auth.CreateRole"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+		slog.String("role", name),
+	)
+
+	log.Info("creating role")
+
+	if !isValidScopeToken(name) {
+		return "", fmt.Errorf("%s %w", op, ErrInvalidRoleName)
+	}
+
+	role := models.Role{AppID: appID, Name: name}
+
+	for _, permission := range permissions {
+		if !isValidScopeToken(permission) {
+			return "", fmt.Errorf("%s %w", op, ErrInvalidPermission)
+		}
+
+		role.Permissions = append(role.Permissions, models.Permission{Name: permission})
+	}
+
+	if _, err := a.appProvider.App(ctx, appID); err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("invalid app id", slog.String("error:", err.Error()))
+
+			return "", fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	id, err := a.roleStorage.SaveRole(ctx, role)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRoleExists) {
+			log.Warn("role already exists")
+
+			return "", fmt.Errorf("%s %w", op, ErrRoleExists)
+		}
+
+		log.Error("failed to save role", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("role created", slog.String("role_id", id))
+
+	return id, nil
+}
+
+// AddRolePermission grants the permission to every user with the role.
+func (a *Auth) AddRolePermission(ctx context.Context, roleID string, permission string) error {
+	const op = "services.auth.AddRolePermission"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("role_id", roleID),
+		slog.String("permission", permission),
+	)
+
+	if !isValidScopeToken(permission) {
+		return fmt.Errorf("%s %w", op, ErrInvalidPermission)
+	}
+
+	if err := a.roleStorage.AddRolePermission(ctx, roleID, permission); err != nil {
+		if errors.Is(err, storage.ErrRoleNotFound) {
+			log.Warn("role not found")
+
+			return fmt.Errorf("%s %w", op, ErrRoleNotFound)
+		}
+
+		log.Error("failed to add permission", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("permission added")
+
+	return nil
+}
+
+// AssignRole gives the role to the user. The user must be a member of the
+// app the role belongs to. Tokens issued afterwards carry the new role.
+func (a *Auth) AssignRole(ctx context.Context, userID string, roleID string) error {
+	const op = "services.auth.AssignRole"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("role_id", roleID),
+	)
+
+	role, err := a.roleStorage.Role(ctx, roleID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRoleNotFound) {
+			log.Warn("role not found")
+
+			return fmt.Errorf("%s %w", op, ErrRoleNotFound)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, userID, role.AppID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is not a member of the app")
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.roleStorage.AssignRole(ctx, userID, roleID); err != nil {
+		log.Error("failed to assign role", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("role assigned")
+
+	return nil
+}
+
+// CheckPermission reports whether any role of the user in the app grants the
+// permission. The caller is authenticated with the access token: an app may
+// check its own users with a token of its own, a user may check their own
+// permissions with their token for the app, and an admin may check anyone.
+func (a *Auth) CheckPermission(
+	ctx context.Context,
+	accessToken string,
+	userID string,
+	appID string,
+	permission string,
+) (bool, error) {
+	const op = "services.auth.CheckPermission"
+
+	if err := a.authorizePermissionCheck(ctx, accessToken, userID, appID); err != nil {
+		if !errors.Is(err, ErrInvalidToken) && !errors.Is(err, ErrPermissionDenied) {
+			a.log.Error("failed to authorize permission check", slog.String("op", op), slog.String("error:", err.Error()))
+		}
+
+		return false, fmt.Errorf("%s %w", op, err)
+	}
+
+	allowed, err := a.roleStorage.HasPermission(ctx, userID, appID, permission)
+
+	if err != nil {
+		a.log.Error("failed to check permission", slog.String("op", op), slog.String("error:", err.Error()))
+
+		return false, fmt.Errorf("%s %w", op, err)
+	}
+
+	return allowed, nil
+}
+
+// authorizePermissionCheck returns ErrPermissionDenied unless the owner of the
+// access token may check the permissions of the user in the app.
+func (a *Auth) authorizePermissionCheck(ctx context.Context, accessToken string, userID string, appID string) error {
+	claims, app, err := a.verifyAccessToken(ctx, accessToken)
+
+	if err != nil {
+		return err
+	}
+
+	if claims.AppID == appID {
+		// A client credentials token has no user: it is the app itself.
+		if claims.ClientID == appID || claims.UID == userID {
+			return nil
+		}
+	}
+
+	// Admin rights are only trusted from tokens signed with a server key, see
+	// VerifyUserToken.
+	if claims.UID == "" || !jwt.IsAsymmetricAlgorithm(jwt.Algorithm(app)) {
+		return ErrPermissionDenied
+	}
+
+	isAdmin, err := a.userProvider.IsAdmin(ctx, claims.UID)
+
+	if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
+		return err
+	}
+
+	if !isAdmin {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"strings"
+	"time"
+)
+
+const (
+	// recoveryCodeCount is the number of recovery codes issued at once.
+	recoveryCodeCount = 10
+	// recoveryCodeBytes gives every code 80 bits of entropy, enough for the
+	// codes to be stored as plain SHA-256 hashes like the opaque tokens.
+	recoveryCodeBytes = 10
+)
+
+// RegenerateRecoveryCodes replaces the recovery codes of the owner of the
+// access token. A code from the authenticator app, or one of the current
+// recovery codes, is required so that a stolen token alone can not be used
+// to get codes that bypass MFA.
+func (a *Auth) RegenerateRecoveryCodes(ctx context.Context, accessToken string, code string) ([]string, error) {
+	const op = "services.auth.RegenerateRecoveryCodes"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, _, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	if !user.TOTPEnabled {
+		log.Warn("mfa is not enabled", slog.String("user_id", user.ID))
+
+		return nil, fmt.Errorf("%s %w", op, ErrMFANotEnabled)
+	}
+
+	if err := a.verifyMFACode(ctx, log, user, code); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	codes, err := a.issueRecoveryCodes(ctx, user.ID)
+
+	if err != nil {
+		log.Error("failed to save recovery codes", slog.String("error:", err.Error()))
+
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("recovery codes regenerated", slog.String("user_id", user.ID))
+
+	return codes, nil
+}
+
+// issueRecoveryCodes replaces the recovery codes of the user with new ones
+// and returns them. They are not stored in plaintext and can not be shown
+// again.
+func (a *Auth) issueRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		code, err := newRecoveryCode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, opaque.Hash(normalizeRecoveryCode(code)))
+	}
+
+	if err := a.mfaStorage.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// useRecoveryCode accepts an unused recovery code of the user once.
+func (a *Auth) useRecoveryCode(ctx context.Context, log *slog.Logger, user models.User, code string) error {
+	err := a.mfaStorage.UseRecoveryCode(ctx, user.ID, opaque.Hash(normalizeRecoveryCode(code)), time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRecoveryCodeNotFound) {
+			log.Info("invalid recovery code", slog.String("user_id", user.ID))
+
+			return ErrInvalidMFACode
+		}
+
+		log.Error("failed to use recovery code", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	log.Info("recovery code used", slog.String("user_id", user.ID))
+
+	return nil
+}
+
+// newRecoveryCode returns a random code formatted for reading out, such as
+// ABCD-EFGH-IJKL-MNOP.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	raw := base32.StdEncoding.EncodeToString(b)
+	groups := make([]string, 0, len(raw)/4)
+
+	for i := 0; i < len(raw); i += 4 {
+		groups = append(groups, raw[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeRecoveryCode makes the dashes, spaces and case of an entered code
+// irrelevant.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SecretSealer encrypts app secrets at rest. The secrets are needed in
+// plaintext to sign and verify HS256 tokens, so they can not only be hashed.
+type SecretSealer interface {
+	Seal(plaintext []byte) (string, error)
+	Open(sealed string) ([]byte, error)
+}
+
+// app returns the app with its secrets decrypted.
+func (a *Auth) app(ctx context.Context, appID string) (models.App, error) {
+	app, err := a.appProvider.App(ctx, appID)
+
+	if err != nil {
+		return models.App{}, err
+	}
+
+	if err := a.openAppSecrets(&app); err != nil {
+		return models.App{}, err
+	}
+
+	return app, nil
+}
+
+func (a *Auth) openAppSecrets(app *models.App) error {
+	secret, err := a.sealer.Open(app.SecretCiphertext)
+
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret of app %s: %w", app.ID, err)
+	}
+
+	app.Secret = string(secret)
+
+	if app.PreviousSecretCiphertext != "" {
+		previous, err := a.sealer.Open(app.PreviousSecretCiphertext)
+
+		if err != nil {
+			return fmt.Errorf("failed to decrypt previous secret of app %s: %w", app.ID, err)
+		}
+
+		app.PreviousSecret = string(previous)
+	}
+
+	return nil
+}
+
+// protectAppSecrets fills the hashes and the ciphertexts of the plaintext
+// secrets of the app.
+func (a *Auth) protectAppSecrets(app *models.App) error {
+	var err error
+
+	app.SecretHash, app.SecretCiphertext, err = a.protectSecret(app.Secret)
+
+	if err != nil {
+		return err
+	}
+
+	if app.PreviousSecret != "" {
+		app.PreviousSecretHash, app.PreviousSecretCiphertext, err = a.protectSecret(app.PreviousSecret)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) protectSecret(secret string) (string, string, error) {
+	hash, err := bcrypt.GenerateFromPassword(prehashSecret(secret), bcrypt.DefaultCost)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	ciphertext, err := a.sealer.Seal([]byte(secret))
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(hash), ciphertext, nil
+}
+
+// checkSecret reports whether secret matches one of the hashes.
+func checkSecret(hashes []string, secret string) bool {
+	prehash := prehashSecret(secret)
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), prehash) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prehashSecret hashes the secret before bcrypt, which ignores everything
+// past 72 bytes.
+func prehashSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+
+	return []byte(hex.EncodeToString(sum[:]))
+}
+
+// MigrateAppSecrets hashes and encrypts the plaintext secrets left by older
+// versions and drops the plaintext columns. It does nothing once the apps
+// have been migrated.
+func (a *Auth) MigrateAppSecrets(ctx context.Context) error {
+	const op = "services.auth.MigrateAppSecrets"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if err := a.appSaver.MigrateLegacyAppSecrets(ctx, a.protectAppSecrets); err != nil {
+		log.Error("failed to migrate app secrets", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,458 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwt"
+	"sso/internal/lib/opaque"
+	"sso/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refresh exchanges a refresh token for a new access/refresh token pair.
+// Refresh tokens are single use: presenting one that was already exchanged
+// is treated as theft and revokes every token of its family.
+func (a *Auth) Refresh(
+	ctx context.Context,
+	refreshToken string,
+	appID string,
+) (models.Tokens, error) {
+	const op = "services.auth.Refresh"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("refreshing tokens")
+
+	stored, err := a.refreshTokenProvider.RefreshToken(ctx, opaque.Hash(refreshToken))
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			log.Warn("refresh token not found")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to get refresh token", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	// A used token is a replay whoever presents it and however old it is, so
+	// reuse is detected before the token is checked against the app.
+	if stored.UsedAt != nil {
+		return models.Tokens{}, fmt.Errorf("%s %w", op, a.revokeReusedRefreshToken(ctx, log, stored))
+	}
+
+	if stored.AppID != appID || time.Now().After(stored.ExpiresAt) {
+		log.Warn("refresh token is expired or issued for another app")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	if err := a.refreshTokenSaver.UseRefreshToken(ctx, stored.ID); err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenUsed) {
+			return models.Tokens{}, fmt.Errorf("%s %w", op, a.revokeReusedRefreshToken(ctx, log, stored))
+		}
+
+		log.Error("failed to use refresh token", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, stored.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.app(ctx, stored.AppID)
+
+	if err != nil {
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is no longer a member of the app")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	tokens, err := a.issueTokens(ctx, user, app, stored.FamilyID, "")
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("tokens refreshed")
+
+	return tokens, nil
+}
+
+// revokeReusedRefreshToken revokes the family of a refresh token that was
+// presented again after use, and returns ErrInvalidToken.
+func (a *Auth) revokeReusedRefreshToken(ctx context.Context, log *slog.Logger, token models.RefreshToken) error {
+	log.Warn("refresh token reuse detected, revoking family", slog.String("family_id", token.FamilyID))
+
+	if err := a.refreshTokenSaver.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+		log.Error("failed to revoke token family", slog.String("error:", err.Error()))
+
+		return err
+	}
+
+	return ErrInvalidToken
+}
+
+// Logout revokes the access token and, when given, the refresh token family
+// of the same session. A revoked access token is rejected until it expires.
+func (a *Auth) Logout(
+	ctx context.Context,
+	accessToken string,
+	refreshToken string,
+) error {
+	const op = "services.auth.Logout"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("logging out user")
+
+	claims, err := a.VerifyAccessToken(ctx, accessToken)
+
+	if err != nil {
+		log.Warn("failed to verify access token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.tokenRevoker.RevokeToken(ctx, claims.JTI, claims.ExpiresAt); err != nil {
+		log.Error("failed to revoke access token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if refreshToken == "" {
+		log.Info("user logged out")
+
+		return nil
+	}
+
+	stored, err := a.refreshTokenProvider.RefreshToken(ctx, opaque.Hash(refreshToken))
+
+	if err != nil {
+		if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+			log.Warn("refresh token not found")
+
+			return nil
+		}
+
+		log.Error("failed to get refresh token", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if stored.UserID != claims.UID {
+		log.Warn("refresh token belongs to another user")
+
+		return nil
+	}
+
+	if err := a.refreshTokenSaver.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+		log.Error("failed to revoke token family", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("user logged out")
+
+	return nil
+}
+
+// Introspect checks that the access token is valid and was issued for the
+// given app, and returns its claims. Any inactive token results in
+// ErrInvalidToken.
+func (a *Auth) Introspect(
+	ctx context.Context,
+	accessToken string,
+	appID string,
+) (jwt.Claims, error) {
+	const op = "services.auth.Introspect"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("introspecting token")
+
+	claims, err := a.VerifyAccessToken(ctx, accessToken)
+
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			log.Info("token is not active")
+		} else {
+			log.Error("failed to verify token", slog.String("error:", err.Error()))
+		}
+
+		return jwt.Claims{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if claims.AppID != appID {
+		log.Info("token is issued for another app")
+
+		return jwt.Claims{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// UserInfo returns the owner of a valid access token.
+func (a *Auth) UserInfo(ctx context.Context, accessToken string) (models.User, error) {
+	const op = "services.auth.UserInfo"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("getting user info")
+
+	claims, err := a.VerifyUserToken(ctx, accessToken)
+
+	if err != nil {
+		log.Warn("failed to verify access token", slog.String("error:", err.Error()))
+
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if claims.UID == "" {
+		log.Warn("access token has no user subject")
+
+		return models.User{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, claims.UID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.User{}, fmt.Errorf("%s %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to get user", slog.String("error:", err.Error()))
+
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return user, nil
+}
+
+// CleanupRevokedTokens drops denylist entries of tokens that have expired,
+// as an expired token is rejected without consulting the denylist.
+func (a *Auth) CleanupRevokedTokens(ctx context.Context) error {
+	const op = "services.auth.CleanupRevokedTokens"
+
+	deleted, err := a.tokenRevoker.DeleteExpiredRevokedTokens(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("revoked tokens cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+// VerifyAccessToken checks the signature, expiry and revocation status of an
+// access token and returns its claims. Tokens of users are also rejected
+// once the user changed the password.
+func (a *Auth) VerifyAccessToken(ctx context.Context, accessToken string) (jwt.Claims, error) {
+	claims, _, err := a.verifyAccessToken(ctx, accessToken)
+
+	return claims, err
+}
+
+// VerifyUserToken is VerifyAccessToken for the tokens that authenticate users
+// to the RPCs of the SSO itself. Only tokens signed with a server key are
+// accepted: HS256 tokens are signed with the app secret, which the owner of
+// the app knows and could sign a token for any user with.
+func (a *Auth) VerifyUserToken(ctx context.Context, accessToken string) (jwt.Claims, error) {
+	claims, app, err := a.verifyAccessToken(ctx, accessToken)
+
+	if err != nil {
+		return jwt.Claims{}, err
+	}
+
+	if !jwt.IsAsymmetricAlgorithm(jwt.Algorithm(app)) {
+		return jwt.Claims{}, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (a *Auth) verifyAccessToken(ctx context.Context, accessToken string) (jwt.Claims, models.App, error) {
+	appID, err := jwt.AppID(accessToken)
+
+	if err != nil {
+		return jwt.Claims{}, models.App{}, ErrInvalidToken
+	}
+
+	app, err := a.app(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return jwt.Claims{}, models.App{}, ErrInvalidToken
+		}
+
+		return jwt.Claims{}, models.App{}, err
+	}
+
+	claims, err := jwt.Parse(accessToken, app, func(kid string) (jwt.Key, error) {
+		return a.keyProvider.VerificationKey(ctx, kid)
+	})
+
+	if err != nil {
+		return jwt.Claims{}, models.App{}, ErrInvalidToken
+	}
+
+	revoked, err := a.tokenRevoker.IsTokenRevoked(ctx, claims.JTI)
+
+	if err != nil {
+		return jwt.Claims{}, models.App{}, err
+	}
+
+	if revoked {
+		return jwt.Claims{}, models.App{}, ErrInvalidToken
+	}
+
+	if claims.UID != "" {
+		user, err := a.userProvider.UserByID(ctx, claims.UID)
+
+		if err != nil {
+			if errors.Is(err, storage.ErrUserNotFound) {
+				return jwt.Claims{}, models.App{}, ErrInvalidToken
+			}
+
+			return jwt.Claims{}, models.App{}, err
+		}
+
+		// The password changed since the token was issued.
+		if user.TokenVersion != claims.TokenVersion {
+			return jwt.Claims{}, models.App{}, ErrInvalidToken
+		}
+	}
+
+	return claims, app, nil
+}
+
+// issueTokens creates an access token, an ID token and a refresh token for
+// the user. An empty familyID starts a new refresh token family.
+func (a *Auth) issueTokens(
+	ctx context.Context,
+	user models.User,
+	app models.App,
+	familyID string,
+	nonce string,
+) (models.Tokens, error) {
+	return a.issueTokensWithID(ctx, user, app, uuid.New().String(), familyID, nonce)
+}
+
+// issueTokensWithID is issueTokens with the id of the access token chosen by
+// the caller, which lets the caller record it before the token exists.
+func (a *Auth) issueTokensWithID(
+	ctx context.Context,
+	user models.User,
+	app models.App,
+	accessTokenID string,
+	familyID string,
+	nonce string,
+) (models.Tokens, error) {
+	key, err := a.signingKey(ctx, app)
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	roles, permissions, err := a.roleStorage.UserAccess(ctx, user.ID, app.ID)
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	accessToken, err := jwt.NewToken(accessTokenID, user, app, roles, permissions, key, a.tokenTTL)
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	idToken, err := jwt.NewIDToken(user, app, key, a.issuer, nonce, a.tokenTTL)
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	refreshToken, err := opaque.NewToken()
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+
+	err = a.refreshTokenSaver.SaveRefreshToken(ctx, models.RefreshToken{
+		TokenHash: opaque.Hash(refreshToken),
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		AppID:     app.ID,
+		ExpiresAt: time.Now().Add(a.refreshTokenTTL),
+	})
+
+	if err != nil {
+		return models.Tokens{}, err
+	}
+
+	return models.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		IDToken:      idToken,
+		ExpiresIn:    a.tokenTTL,
+	}, nil
+}
+
+// signingKey returns the current server key for apps using an asymmetric
+// algorithm. HS256 apps sign with their secret and get an empty key.
+func (a *Auth) signingKey(ctx context.Context, app models.App) (jwt.Key, error) {
+	alg := jwt.Algorithm(app)
+
+	if !jwt.IsAsymmetricAlgorithm(alg) {
+		return jwt.Key{}, nil
+	}
+
+	return a.keyProvider.SigningKey(ctx, alg)
+}
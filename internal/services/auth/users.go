@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/storage"
+)
+
+// GetUser returns the user with the apps they are a member of.
+func (a *Auth) GetUser(ctx context.Context, userID string) (models.User, error) {
+	const op = "services.auth.GetUser"
+
+	user, err := a.userProvider.UserByID(ctx, userID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return models.User{}, fmt.Errorf("%s %w", op, ErrUserNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	users := []models.User{user}
+
+	if err := a.loadUserApps(ctx, users); err != nil {
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return users[0], nil
+}
+
+// ListUsers returns a page of the users matching the filter and the token of
+// the next page, which is empty on the last page.
+func (a *Auth) ListUsers(
+	ctx context.Context,
+	filter models.UserFilter,
+	pageToken string,
+) ([]models.User, string, error) {
+	const op = "services.auth.ListUsers"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	pageSize := normalizePageSize(filter.Limit)
+
+	after, err := decodePageToken(pageToken)
+
+	if err != nil {
+		log.Warn("invalid page token")
+
+		return nil, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	filter.After = after
+	// One extra user tells whether there is a next page.
+	filter.Limit = pageSize + 1
+
+	users, err := a.userProvider.Users(ctx, filter)
+
+	if err != nil {
+		log.Error("failed to list users", slog.String("error:", err.Error()))
+
+		return nil, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	var nextPageToken string
+
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		last := users[pageSize-1]
+		nextPageToken = encodePageToken(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	if err := a.loadUserApps(ctx, users); err != nil {
+		return nil, "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return users, nextPageToken, nil
+}
+
+// UpdateUser changes the fields of the user set in the update and returns the
+// updated user.
+func (a *Auth) UpdateUser(ctx context.Context, userID string, update models.UserUpdate) (models.User, error) {
+	const op = "services.auth.UpdateUser"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	if update.Email != nil {
+		if err := validateEmail(*update.Email); err != nil {
+			return models.User{}, fmt.Errorf("%s %w", op, err)
+		}
+	}
+
+	if err := a.userSaver.UpdateUser(ctx, userID, update); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			log.Warn("user not found")
+
+			return models.User{}, fmt.Errorf("%s %w", op, ErrUserNotFound)
+		case errors.Is(err, storage.ErrUserExists):
+			log.Warn("email is taken")
+
+			return models.User{}, fmt.Errorf("%s %w", op, ErrUserExists)
+		}
+
+		log.Error("failed to update user", slog.String("error:", err.Error()))
+
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("user updated")
+
+	user, err := a.GetUser(ctx, userID)
+
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return user, nil
+}
+
+// DeleteUser soft deletes the user. A deleted user can not sign in and their
+// refresh tokens are revoked.
+func (a *Auth) DeleteUser(ctx context.Context, userID string) error {
+	const op = "services.auth.DeleteUser"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	if err := a.userSaver.DeleteUser(ctx, userID); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found")
+
+			return fmt.Errorf("%s %w", op, ErrUserNotFound)
+		}
+
+		log.Error("failed to delete user", slog.String("error:", err.Error()))
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("user deleted")
+
+	return nil
+}
+
+func (a *Auth) loadUserApps(ctx context.Context, users []models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	apps, err := a.userProvider.UserApps(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range users {
+		users[i].AppIDs = apps[users[i].ID]
+	}
+
+	return nil
+}
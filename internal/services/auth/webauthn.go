@@ -0,0 +1,508 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sso/internal/domain/models"
+	"sso/internal/lib/opaque"
+	"sso/internal/lib/webauthn"
+	"sso/internal/storage"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+var (
+	ErrWebAuthnNotConfigured    = errors.New("webauthn is not configured for the app")
+	ErrInvalidWebAuthnRPID      = errors.New("invalid webauthn rp id")
+	ErrInvalidWebAuthnOrigin    = errors.New("invalid webauthn origin")
+	ErrInvalidWebAuthnResponse  = errors.New("invalid webauthn response")
+	ErrWebAuthnCredentialExists = errors.New("webauthn credential already registered")
+)
+
+// BeginWebAuthnRegistration starts the registration of a passkey for the
+// owner of the access token with the relying party of the token's app. It
+// returns the session ID to pass to FinishWebAuthnRegistration and the
+// options for navigator.credentials.create().
+func (a *Auth) BeginWebAuthnRegistration(
+	ctx context.Context,
+	accessToken string,
+) (string, webauthn.CreationOptions, error) {
+	const op = "services.auth.BeginWebAuthnRegistration"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, claims, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return "", webauthn.CreationOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	rp, err := a.relyingParty(ctx, claims.AppID)
+
+	if err != nil {
+		log.Warn("relying party unavailable", slog.String("error:", err.Error()))
+
+		return "", webauthn.CreationOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	credentials, err := a.webAuthnStorage.WebAuthnCredentials(ctx, user.ID, rp.ID)
+
+	if err != nil {
+		log.Error("failed to get webauthn credentials", slog.String("error:", err.Error()))
+
+		return "", webauthn.CreationOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	existing := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		id, err := webauthn.DecodeID(credential.ID)
+		if err != nil {
+			return "", webauthn.CreationOptions{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		existing = append(existing, id)
+	}
+
+	sessionID, challenge, err := a.startWebAuthnSession(ctx, models.WebAuthnSession{
+		Ceremony: models.WebAuthnRegistration,
+		UserID:   user.ID,
+		AppID:    claims.AppID,
+	})
+
+	if err != nil {
+		log.Error("failed to save webauthn session", slog.String("error:", err.Error()))
+
+		return "", webauthn.CreationOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("webauthn registration started", slog.String("user_id", user.ID))
+
+	options := rp.NewCreationOptions(challenge, []byte(user.ID), user.Email, existing, a.webAuthnSessionTTL)
+
+	return sessionID, options, nil
+}
+
+// FinishWebAuthnRegistration verifies the response of the authenticator to
+// a registration started by BeginWebAuthnRegistration and stores the new
+// credential under the name. It returns the base64url encoded credential ID.
+func (a *Auth) FinishWebAuthnRegistration(
+	ctx context.Context,
+	accessToken string,
+	sessionID string,
+	name string,
+	clientDataJSON []byte,
+	attestationObject []byte,
+) (string, error) {
+	const op = "services.auth.FinishWebAuthnRegistration"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, claims, err := a.tokenUser(ctx, log, accessToken)
+
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	session, err := a.useWebAuthnSession(ctx, sessionID, models.WebAuthnRegistration)
+
+	if err != nil {
+		log.Warn("invalid webauthn session", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	if session.UserID != user.ID {
+		log.Warn("webauthn session of another user", slog.String("user_id", user.ID))
+
+		return "", fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	if session.AppID != claims.AppID {
+		log.Warn("webauthn session of another app", slog.String("app_id", claims.AppID))
+
+		return "", fmt.Errorf("%s %w", op, ErrInvalidToken)
+	}
+
+	rp, err := a.relyingParty(ctx, session.AppID)
+
+	if err != nil {
+		log.Warn("relying party unavailable", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	credential, err := rp.VerifyRegistration(session.Challenge, clientDataJSON, attestationObject)
+
+	if err != nil {
+		log.Warn("invalid webauthn registration", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, ErrInvalidWebAuthnResponse)
+	}
+
+	id := webauthn.EncodeID(credential.ID)
+
+	err = a.webAuthnStorage.SaveWebAuthnCredential(ctx, models.WebAuthnCredential{
+		ID:        id,
+		UserID:    user.ID,
+		RPID:      rp.ID,
+		Name:      strings.TrimSpace(name),
+		PublicKey: credential.PublicKey,
+		SignCount: credential.SignCount,
+	})
+
+	if err != nil {
+		if errors.Is(err, storage.ErrWebAuthnCredentialExists) {
+			log.Warn("webauthn credential already registered")
+
+			return "", fmt.Errorf("%s %w", op, ErrWebAuthnCredentialExists)
+		}
+
+		log.Error("failed to save webauthn credential", slog.String("error:", err.Error()))
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("webauthn credential registered", slog.String("user_id", user.ID))
+
+	return id, nil
+}
+
+// BeginWebAuthnLogin starts a passwordless login to the app. It returns the
+// session ID to pass to FinishWebAuthnLogin and the options for
+// navigator.credentials.get(). The options allow any passkey of the relying
+// party, so they reveal nothing about which users are registered.
+func (a *Auth) BeginWebAuthnLogin(
+	ctx context.Context,
+	appID string,
+	nonce string,
+) (string, webauthn.RequestOptions, error) {
+	const op = "services.auth.BeginWebAuthnLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("app_id", appID),
+	)
+
+	rp, err := a.relyingParty(ctx, appID)
+
+	if err != nil {
+		log.Warn("relying party unavailable", slog.String("error:", err.Error()))
+
+		return "", webauthn.RequestOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	sessionID, challenge, err := a.startWebAuthnSession(ctx, models.WebAuthnSession{
+		Ceremony: models.WebAuthnLogin,
+		AppID:    appID,
+		Nonce:    nonce,
+	})
+
+	if err != nil {
+		log.Error("failed to save webauthn session", slog.String("error:", err.Error()))
+
+		return "", webauthn.RequestOptions{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return sessionID, rp.NewRequestOptions(challenge, nil, a.webAuthnSessionTTL), nil
+}
+
+// FinishWebAuthnLogin verifies the assertion of a passkey for a login
+// started by BeginWebAuthnLogin and issues tokens to its owner. Passkeys
+// verify the user themselves, so no MFA code is asked for.
+func (a *Auth) FinishWebAuthnLogin(
+	ctx context.Context,
+	sessionID string,
+	credentialID []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+	userHandle []byte,
+) (models.Tokens, error) {
+	const op = "services.auth.FinishWebAuthnLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	session, err := a.useWebAuthnSession(ctx, sessionID, models.WebAuthnLogin)
+
+	if err != nil {
+		log.Warn("invalid webauthn session", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	credential, err := a.webAuthnStorage.WebAuthnCredential(ctx, webauthn.EncodeID(credentialID))
+
+	if err != nil {
+		if errors.Is(err, storage.ErrWebAuthnCredentialNotFound) {
+			log.Warn("webauthn credential not found")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		}
+
+		log.Error("failed to get webauthn credential", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	app, err := a.app(ctx, session.AppID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidAppID)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if app.WebAuthnRPID == "" || credential.RPID != app.WebAuthnRPID {
+		log.Warn("webauthn credential of another relying party", slog.String("rp_id", credential.RPID))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+	}
+
+	if len(userHandle) != 0 && string(userHandle) != credential.UserID {
+		log.Warn("user handle does not match the credential")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+	}
+
+	rp := webauthn.RelyingParty{ID: app.WebAuthnRPID, Name: app.Name, Origins: app.WebAuthnOrigins}
+
+	signCount, err := rp.VerifyAssertion(
+		session.Challenge,
+		credential.PublicKey,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+	)
+
+	if err != nil {
+		log.Warn("invalid webauthn assertion", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+	}
+
+	// Authenticators without a counter always report 0; any other counter
+	// has to increase, or the credential may have been cloned.
+	if (signCount != 0 || credential.SignCount != 0) && signCount <= credential.SignCount {
+		log.Warn(
+			"webauthn signature counter did not increase",
+			slog.String("user_id", credential.UserID),
+			slog.Uint64("sign_count", uint64(signCount)),
+		)
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+	}
+
+	err = a.webAuthnStorage.UseWebAuthnCredential(ctx, credential.ID, credential.SignCount, signCount, time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrWebAuthnCredentialNotFound) {
+			log.Warn("webauthn credential used concurrently")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	user, err := a.userProvider.UserByID(ctx, credential.UserID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error:", err.Error()))
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := a.checkMembership(ctx, user.ID, app.ID); err != nil {
+		if errors.Is(err, ErrNotMember) {
+			log.Warn("user is not a member of the app")
+
+			return models.Tokens{}, fmt.Errorf("%s %w", op, ErrInvalidCredentials)
+		}
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := checkEmailVerified(user, app); err != nil {
+		log.Warn("email is not verified")
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("user logged in with webauthn", slog.String("user_id", user.ID))
+
+	tokens, err := a.issueTokens(ctx, user, app, "", session.Nonce)
+
+	if err != nil {
+		log.Error("failed to generate tokens", slog.String("error:", err.Error()))
+
+		return models.Tokens{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return tokens, nil
+}
+
+// relyingParty returns the relying party of the app, or
+// ErrWebAuthnNotConfigured when the app has no RP ID.
+func (a *Auth) relyingParty(ctx context.Context, appID string) (webauthn.RelyingParty, error) {
+	app, err := a.appProvider.App(ctx, appID)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return webauthn.RelyingParty{}, ErrInvalidAppID
+		}
+
+		return webauthn.RelyingParty{}, err
+	}
+
+	if app.WebAuthnRPID == "" {
+		return webauthn.RelyingParty{}, ErrWebAuthnNotConfigured
+	}
+
+	return webauthn.RelyingParty{ID: app.WebAuthnRPID, Name: app.Name, Origins: app.WebAuthnOrigins}, nil
+}
+
+// startWebAuthnSession saves the session with a new challenge and returns
+// the session ID and the challenge.
+func (a *Auth) startWebAuthnSession(ctx context.Context, session models.WebAuthnSession) (string, string, error) {
+	sessionID, err := opaque.NewToken()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge, err := opaque.NewToken()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	session.IDHash = opaque.Hash(sessionID)
+	session.Challenge = challenge
+	session.ExpiresAt = time.Now().Add(a.webAuthnSessionTTL)
+
+	if err := a.webAuthnStorage.SaveWebAuthnSession(ctx, session); err != nil {
+		return "", "", err
+	}
+
+	return sessionID, challenge, nil
+}
+
+// useWebAuthnSession consumes the session, so that a failed ceremony has to
+// be started over.
+func (a *Auth) useWebAuthnSession(ctx context.Context, sessionID string, ceremony string) (models.WebAuthnSession, error) {
+	session, err := a.webAuthnStorage.UseWebAuthnSession(ctx, opaque.Hash(sessionID), ceremony, time.Now())
+
+	if err != nil {
+		if errors.Is(err, storage.ErrWebAuthnSessionNotFound) {
+			return models.WebAuthnSession{}, ErrInvalidToken
+		}
+
+		return models.WebAuthnSession{}, err
+	}
+
+	return session, nil
+}
+
+// validateWebAuthnRPID accepts an empty RP ID, which disables WebAuthn for
+// the app, or a lowercase domain without scheme and port. Public suffixes
+// such as com or co.uk are rejected, as their credentials would be shared
+// by every site under them; localhost is allowed for development.
+func validateWebAuthnRPID(rpID string) error {
+	if rpID == "" {
+		return nil
+	}
+
+	for _, label := range strings.Split(rpID, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return ErrInvalidWebAuthnRPID
+		}
+
+		if strings.ContainsFunc(label, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-')
+		}) {
+			return ErrInvalidWebAuthnRPID
+		}
+	}
+
+	if rpID == "localhost" {
+		return nil
+	}
+
+	if _, err := publicsuffix.EffectiveTLDPlusOne(rpID); err != nil {
+		return ErrInvalidWebAuthnRPID
+	}
+
+	return nil
+}
+
+// validateWebAuthnOrigins checks the origins allowed for the RP ID, which
+// must be set when there are any.
+func validateWebAuthnOrigins(rpID string, origins []string) error {
+	if len(origins) != 0 && rpID == "" {
+		return ErrInvalidWebAuthnOrigin
+	}
+
+	for _, origin := range origins {
+		if err := webauthn.ValidateOrigin(rpID, origin); err != nil {
+			return ErrInvalidWebAuthnOrigin
+		}
+	}
+
+	return nil
+}
+
+// validateWebAuthnUpdate checks the RP ID and the origins the app ends up
+// with after the update, as either may change without the other.
+func (a *Auth) validateWebAuthnUpdate(ctx context.Context, appID string, update models.AppUpdate) error {
+	app, err := a.GetApp(ctx, appID)
+
+	if err != nil {
+		return err
+	}
+
+	if update.WebAuthnRPID != nil {
+		app.WebAuthnRPID = *update.WebAuthnRPID
+	}
+
+	if update.WebAuthnOrigins != nil {
+		app.WebAuthnOrigins = *update.WebAuthnOrigins
+	}
+
+	if err := validateWebAuthnRPID(app.WebAuthnRPID); err != nil {
+		return err
+	}
+
+	return validateWebAuthnOrigins(app.WebAuthnRPID, app.WebAuthnOrigins)
+}
+
+func (a *Auth) CleanupWebAuthnSessions(ctx context.Context) error {
+	const op = "services.auth.CleanupWebAuthnSessions"
+
+	deleted, err := a.webAuthnStorage.DeleteExpiredWebAuthnSessions(ctx, time.Now())
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	a.log.Debug("webauthn sessions cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
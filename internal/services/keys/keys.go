@@ -0,0 +1,338 @@
+package keys
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sso/internal/domain/models"
+	"sso/internal/lib/jwk"
+	"sso/internal/lib/jwt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound          = errors.New("key not found")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
+// reloadMinInterval limits how often an unknown kid triggers a reload of the
+// key ring from storage.
+const reloadMinInterval = 10 * time.Second
+
+// Keys is the ring of server key pairs used to sign tokens of apps that chose
+// an asymmetric algorithm. For every algorithm one key is active and used to
+// sign new tokens; retired keys are still published for verification during
+// the overlap window, which must not be shorter than the token lifetime.
+type Keys struct {
+	log            *slog.Logger
+	keySaver       KeySaver
+	keyProvider    KeyProvider
+	sealer         Sealer
+	overlap        time.Duration
+	rotationPeriod time.Duration
+
+	mu         sync.RWMutex
+	keys       map[string]jwt.Key
+	active     map[string]models.SigningKey
+	lastReload time.Time
+}
+
+type KeySaver interface {
+	RotateSigningKey(ctx context.Context, key models.SigningKey) error
+	DeleteSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error)
+	MigrateLegacySigningKeys(ctx context.Context, seal func(plaintext []byte) (string, error)) error
+}
+
+type KeyProvider interface {
+	SigningKeys(ctx context.Context, retiredAfter time.Time) ([]models.SigningKey, error)
+}
+
+// Sealer encrypts the private keys at rest.
+type Sealer interface {
+	Seal(plaintext []byte) (string, error)
+	Open(sealed string) ([]byte, error)
+}
+
+// New loads the key ring from storage. Algorithms without an active key get
+// one imported from the PEM file in keyPaths or, if no path is configured,
+// a freshly generated one. Private keys stored in plaintext by older
+// versions are encrypted first.
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	keySaver KeySaver,
+	keyProvider KeyProvider,
+	sealer Sealer,
+	keyPaths map[string]string,
+	overlap time.Duration,
+	rotationPeriod time.Duration,
+) (*Keys, error) {
+	const op = "services.keys.New"
+
+	k := &Keys{
+		log:            log,
+		keySaver:       keySaver,
+		keyProvider:    keyProvider,
+		sealer:         sealer,
+		overlap:        overlap,
+		rotationPeriod: rotationPeriod,
+	}
+
+	if err := keySaver.MigrateLegacySigningKeys(ctx, sealer.Seal); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := k.Reload(ctx); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	for _, alg := range jwt.AsymmetricAlgorithms {
+		if _, ok := k.activeKey(alg); ok {
+			continue
+		}
+
+		private, err := initialKey(alg, keyPaths[alg])
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		if _, err := k.rotate(ctx, alg, private); err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		log.Info("signing key created", slog.String("op", op), slog.String("alg", alg))
+	}
+
+	return k, nil
+}
+
+func initialKey(alg string, path string) (crypto.Signer, error) {
+	if path == "" {
+		return jwt.GenerateKey(alg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.ParsePrivateKey(alg, data)
+}
+
+// Reload replaces the in-memory key ring with the keys from storage, so keys
+// rotated by another replica become known.
+func (k *Keys) Reload(ctx context.Context) error {
+	const op = "services.keys.Reload"
+
+	stored, err := k.keyProvider.SigningKeys(ctx, time.Now().Add(-k.overlap))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	keys := make(map[string]jwt.Key, len(stored))
+	active := make(map[string]models.SigningKey)
+
+	for _, s := range stored {
+		pem, err := k.sealer.Open(s.PrivateKeyCiphertext)
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		private, err := jwt.ParsePrivateKey(s.Algorithm, pem)
+		if err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		keys[s.ID] = jwt.Key{ID: s.ID, Algorithm: s.Algorithm, Private: private}
+
+		if s.RetiredAt == nil {
+			active[s.Algorithm] = s
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys = keys
+	k.active = active
+	k.lastReload = time.Now()
+
+	return nil
+}
+
+// Rotate creates a new active key for alg, or for every asymmetric algorithm
+// when alg is empty. Previously active keys are retired but stay published
+// for verification until the overlap window has passed.
+func (k *Keys) Rotate(ctx context.Context, alg string) ([]jwt.Key, error) {
+	const op = "services.keys.Rotate"
+
+	algs := jwt.AsymmetricAlgorithms
+	if alg != "" {
+		if !jwt.IsAsymmetricAlgorithm(alg) {
+			return nil, fmt.Errorf("%s %w", op, ErrUnsupportedAlgorithm)
+		}
+
+		algs = []string{alg}
+	}
+
+	rotated := make([]jwt.Key, 0, len(algs))
+
+	for _, alg := range algs {
+		private, err := jwt.GenerateKey(alg)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		key, err := k.rotate(ctx, alg, private)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", op, err)
+		}
+
+		k.log.Info("signing key rotated", slog.String("op", op), slog.String("alg", alg), slog.String("kid", key.ID))
+
+		rotated = append(rotated, key)
+	}
+
+	return rotated, nil
+}
+
+func (k *Keys) rotate(ctx context.Context, alg string, private crypto.Signer) (jwt.Key, error) {
+	kid, err := jwk.Thumbprint(private.Public())
+	if err != nil {
+		return jwt.Key{}, err
+	}
+
+	pem, err := jwt.MarshalPrivateKey(private)
+	if err != nil {
+		return jwt.Key{}, err
+	}
+
+	ciphertext, err := k.sealer.Seal(pem)
+	if err != nil {
+		return jwt.Key{}, err
+	}
+
+	err = k.keySaver.RotateSigningKey(ctx, models.SigningKey{
+		ID:                   kid,
+		Algorithm:            alg,
+		PrivateKeyCiphertext: ciphertext,
+	})
+	if err != nil {
+		return jwt.Key{}, err
+	}
+
+	if err := k.Reload(ctx); err != nil {
+		return jwt.Key{}, err
+	}
+
+	return jwt.Key{ID: kid, Algorithm: alg, Private: private}, nil
+}
+
+// RotateDue is run on schedule. It rotates active keys older than the
+// rotation period and deletes keys that are no longer published.
+func (k *Keys) RotateDue(ctx context.Context) error {
+	const op = "services.keys.RotateDue"
+
+	if err := k.Reload(ctx); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if k.rotationPeriod > 0 {
+		for _, alg := range jwt.AsymmetricAlgorithms {
+			key, ok := k.activeKey(alg)
+			if ok && time.Since(key.CreatedAt) < k.rotationPeriod {
+				continue
+			}
+
+			if _, err := k.Rotate(ctx, alg); err != nil {
+				return fmt.Errorf("%s %w", op, err)
+			}
+		}
+	}
+
+	deleted, err := k.keySaver.DeleteSigningKeys(ctx, time.Now().Add(-k.overlap))
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	k.log.Debug("retired signing keys cleaned up", slog.String("op", op), slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+func (k *Keys) activeKey(alg string) (models.SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.active[alg]
+
+	return key, ok
+}
+
+// SigningKey returns the active key new tokens of the given algorithm are
+// signed with.
+func (k *Keys) SigningKey(ctx context.Context, alg string) (jwt.Key, error) {
+	const op = "services.keys.SigningKey"
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	active, ok := k.active[alg]
+	if !ok {
+		return jwt.Key{}, fmt.Errorf("%s %w", op, ErrKeyNotFound)
+	}
+
+	return k.keys[active.ID], nil
+}
+
+// VerificationKey returns the active or retired key with the given id.
+func (k *Keys) VerificationKey(ctx context.Context, kid string) (jwt.Key, error) {
+	const op = "services.keys.VerificationKey"
+
+	if key, ok := k.key(kid); ok {
+		return key, nil
+	}
+
+	k.mu.RLock()
+	stale := time.Since(k.lastReload) > reloadMinInterval
+	k.mu.RUnlock()
+
+	if stale {
+		if err := k.Reload(ctx); err != nil {
+			return jwt.Key{}, fmt.Errorf("%s %w", op, err)
+		}
+
+		if key, ok := k.key(kid); ok {
+			return key, nil
+		}
+	}
+
+	return jwt.Key{}, fmt.Errorf("%s %w", op, ErrKeyNotFound)
+}
+
+func (k *Keys) key(kid string) (jwt.Key, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+
+	return key, ok
+}
+
+// PublicKeys returns every key that tokens may be verified with.
+func (k *Keys) PublicKeys(ctx context.Context) ([]jwt.Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	keys := make([]jwt.Key, 0, len(k.keys))
+
+	for _, key := range k.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -2,29 +2,36 @@ package postgres
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"sso/internal/config"
 	"sso/internal/domain/models"
+	"sso/internal/lib/ratelimit"
 	"sso/internal/storage"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
 	UniqueConstraintEmail = "uni_users_email"
 	UniqueConstraintApp   = "uni_apps_name"
+	UniqueConstraintRole  = "idx_roles_app_name"
+
+	UniqueConstraintWebAuthnCredential = "web_authn_credentials_pkey"
 )
 
 func IsUniqueConstraintError(err error, constraintName string) bool {
-
 	if pqErr, ok := err.(*pgconn.PgError); ok {
 		return pqErr.Code == "23505" && pqErr.ConstraintName == constraintName
 	}
-	fmt.Println(err)
+
 	return false
 }
 
@@ -46,15 +53,61 @@ func New(cfg config.StorageConfig) (*Storage, error) {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
-	err = db.AutoMigrate(&models.User{}, &models.App{})
+	err = db.AutoMigrate(
+		&models.User{},
+		&models.App{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.SigningKey{},
+		&models.AuthorizationCode{},
+		&models.DeviceCode{},
+		&models.Membership{},
+		&models.Role{},
+		&models.Permission{},
+		&models.UserRole{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+		&models.MFAChallenge{},
+		&models.RecoveryCode{},
+		&models.WebAuthnCredential{},
+		&models.WebAuthnSession{},
+		&models.EmailLogin{},
+		&models.LoginThrottle{},
+		&models.RateLimitBucket{},
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("%s %w", op, err)
 	}
 
+	if err := migrateUserApps(db); err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
 	return &Storage{db: db}, nil
 }
 
+// migrateUserApps moves the app of users created before memberships were
+// introduced into the memberships table and drops the users.app_id column.
+func migrateUserApps(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.User{}, "app_id") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO memberships (user_id, app_id, created_at)
+			SELECT id, app_id, created_at FROM users WHERE app_id IS NOT NULL
+			ON CONFLICT DO NOTHING`).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&models.User{}, "app_id")
+	})
+}
+
+// SaverUser creates the user identity together with its membership in the
+// app it registered in.
 func (s *Storage) SaverUser(
 	ctx context.Context,
 	email string,
@@ -65,26 +118,84 @@ func (s *Storage) SaverUser(
 
 	uid := uuid.New().String()
 
-	user := models.User{ID: uid, Email: email, Passhash: passHash, AppID: app_id}
+	user := models.User{ID: uid, Email: email, Passhash: passHash}
 
-	tx := s.db.WithContext(ctx).Create(&user)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
 
-	if tx.Error != nil {
-		if IsUniqueConstraintError(tx.Error, UniqueConstraintEmail) {
+		return tx.Create(&models.Membership{UserID: uid, AppID: app_id}).Error
+	})
+
+	if err != nil {
+		if IsUniqueConstraintError(err, UniqueConstraintEmail) {
 			return "", fmt.Errorf("%s %w", op, storage.ErrUserExists)
 		}
 
-		return "", fmt.Errorf("%s %w", op, tx.Error)
+		return "", fmt.Errorf("%s %w", op, err)
 	}
 
 	return uid, nil
 }
 
+func (s *Storage) AddMembership(ctx context.Context, userID string, appID string) error {
+	const op = "storage.postgres.AddMembership"
+
+	tx := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.Membership{UserID: userID, AppID: appID})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrMembershipExists)
+	}
+
+	return nil
+}
+
+func (s *Storage) IsMember(ctx context.Context, userID string, appID string) (bool, error) {
+	const op = "storage.postgres.IsMember"
+
+	var count int64
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.Membership{}).
+		Where("user_id = ? AND app_id = ?", userID, appID).
+		Count(&count)
+
+	if tx.Error != nil {
+		return false, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return count > 0, nil
+}
+
 func (s *Storage) User(ctx context.Context, email string) (models.User, error) {
-	const op = "storage.postgres.SaveUser"
+	const op = "storage.postgres.User"
+
+	var user models.User
+	tx := s.db.WithContext(ctx).First(&user, "email = ?", email)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return models.User{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) UserByID(ctx context.Context, userID string) (models.User, error) {
+	const op = "storage.postgres.UserByID"
 
 	var user models.User
-	tx := s.db.WithContext(ctx).Find(&user, "email = ?", email)
+	tx := s.db.WithContext(ctx).First(&user, "id = ?", userID)
 
 	if tx.Error != nil {
 		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
@@ -97,15 +208,185 @@ func (s *Storage) User(ctx context.Context, email string) (models.User, error) {
 	return user, nil
 }
 
+// Users returns the users matching the filter ordered by creation time.
+func (s *Storage) Users(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	const op = "storage.postgres.Users"
+
+	tx := s.db.WithContext(ctx).Order("created_at, id").Limit(filter.Limit)
+
+	if filter.AppID != "" {
+		tx = tx.Where(
+			"EXISTS (SELECT 1 FROM memberships WHERE memberships.user_id = users.id AND memberships.app_id = ?)",
+			filter.AppID,
+		)
+	}
+
+	if filter.EmailPrefix != "" {
+		tx = tx.Where("email LIKE ?", escapeLike(filter.EmailPrefix)+"%")
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		tx = tx.Where("created_at >= ?", filter.CreatedAfter)
+	}
+
+	if !filter.CreatedBefore.IsZero() {
+		tx = tx.Where("created_at < ?", filter.CreatedBefore)
+	}
+
+	if filter.After != nil {
+		tx = tx.Where("(created_at, id) > (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var users []models.User
+
+	if err := tx.Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return users, nil
+}
+
+// UserApps returns the ids of the apps each of the users is a member of.
+func (s *Storage) UserApps(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	const op = "storage.postgres.UserApps"
+
+	var memberships []models.Membership
+
+	tx := s.db.WithContext(ctx).Where("user_id IN ?", userIDs).Order("created_at").Find(&memberships)
+
+	if tx.Error != nil {
+		return nil, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	apps := make(map[string][]string, len(userIDs))
+	for _, membership := range memberships {
+		apps[membership.UserID] = append(apps[membership.UserID], membership.AppID)
+	}
+
+	return apps, nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, userID string, update models.UserUpdate) error {
+	const op = "storage.postgres.UpdateUser"
+
+	values := map[string]any{}
+
+	if update.Email != nil {
+		values["email"] = *update.Email
+		// The right side sees the old email, so the flag survives only an
+		// update to the same email.
+		values["email_verified"] = gorm.Expr("email_verified AND email = ?", *update.Email)
+	}
+
+	if update.IsAdmin != nil {
+		values["is_admin"] = *update.IsAdmin
+	}
+
+	if len(values) == 0 {
+		if _, err := s.UserByID(ctx, userID); err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		return nil
+	}
+
+	tx := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Updates(values)
+
+	if tx.Error != nil {
+		if IsUniqueConstraintError(tx.Error, UniqueConstraintEmail) {
+			return fmt.Errorf("%s %w", op, storage.ErrUserExists)
+		}
+
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// DeleteUser soft deletes the user and revokes their refresh tokens. The
+// email of a deleted user stays taken.
+func (s *Storage) DeleteUser(ctx context.Context, userID string) error {
+	const op = "storage.postgres.DeleteUser"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Delete(&models.User{}, "id = ?", userID)
+
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+
+		if deleted.RowsAffected == 0 {
+			return storage.ErrUserNotFound
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the user, increments their
+// token version and revokes their refresh tokens, which ends every session
+// of the user.
+func (s *Storage) UpdatePassword(ctx context.Context, userID string, passHash []byte) error {
+	const op = "storage.postgres.UpdatePassword"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updatePassword(tx, userID, passHash, time.Now())
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// updatePassword replaces the password hash of the user within tx, see
+// UpdatePassword.
+func updatePassword(tx *gorm.DB, userID string, passHash []byte, now time.Time) error {
+	updated := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"passhash":      passHash,
+		"token_version": gorm.Expr("token_version + 1"),
+	})
+
+	if updated.Error != nil {
+		return updated.Error
+	}
+
+	if updated.RowsAffected == 0 {
+		return storage.ErrUserNotFound
+	}
+
+	return tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (s *Storage) App(ctx context.Context, appID string) (models.App, error) {
 	const op = "storage.postgres.App"
 
 	var app models.App
-	tx := s.db.WithContext(ctx).Find(&app, "ID = ?", appID)
+	tx := s.db.WithContext(ctx).First(&app, "id = ?", appID)
 
 	if tx.Error != nil {
 		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
-			return models.App{}, fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+			return models.App{}, fmt.Errorf("%s %w", op, storage.ErrAppNotFound)
 		}
 
 		return models.App{}, fmt.Errorf("%s %w", op, tx.Error)
@@ -114,11 +395,11 @@ func (s *Storage) App(ctx context.Context, appID string) (models.App, error) {
 	return app, nil
 }
 
-func (s *Storage) IsAdmin(ctx context.Context, email string) (bool, error) {
-	const op = "storage.postgres.SaveUser"
+func (s *Storage) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	const op = "storage.postgres.IsAdmin"
 
 	var user models.User
-	tx := s.db.WithContext(ctx).Find(&user, "email = ?", email)
+	tx := s.db.WithContext(ctx).First(&user, "id = ?", userID)
 
 	if tx.Error != nil {
 		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
@@ -131,21 +412,32 @@ func (s *Storage) IsAdmin(ctx context.Context, email string) (bool, error) {
 	return user.IsAdmin, nil
 }
 
-func (s *Storage) SaveApp(
-	ctx context.Context,
-	name string,
-	secret string,
-) (string, error) {
-	const op = "storage.postgres.SaveApp"
+func (s *Storage) SetAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	const op = "storage.postgres.SetAdmin"
+
+	tx := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("is_admin", isAdmin)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
 
-	appId := uuid.New().String()
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveApp(ctx context.Context, app models.App) (string, error) {
+	const op = "storage.postgres.SaveApp"
 
-	app := models.App{ID: appId, Name: name, Secret: secret}
+	if app.ID == "" {
+		app.ID = uuid.New().String()
+	}
 
 	tx := s.db.WithContext(ctx).Create(&app)
 
 	if tx.Error != nil {
-		fmt.Println(tx.Error)
 		if IsUniqueConstraintError(tx.Error, UniqueConstraintApp) {
 			return "", fmt.Errorf("%s %w", op, storage.ErrAppExists)
 		}
@@ -153,5 +445,1666 @@ func (s *Storage) SaveApp(
 		return "", fmt.Errorf("%s %w", op, tx.Error)
 	}
 
-	return appId, nil
+	return app.ID, nil
+}
+
+// Apps returns the apps ordered by creation time.
+func (s *Storage) Apps(ctx context.Context, filter models.AppFilter) ([]models.App, error) {
+	const op = "storage.postgres.Apps"
+
+	tx := s.db.WithContext(ctx).Order("created_at, id").Limit(filter.Limit)
+
+	if filter.After != nil {
+		tx = tx.Where("(created_at, id) > (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var apps []models.App
+
+	if err := tx.Find(&apps).Error; err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return apps, nil
+}
+
+func (s *Storage) UpdateApp(ctx context.Context, appID string, update models.AppUpdate) error {
+	const op = "storage.postgres.UpdateApp"
+
+	var app models.App
+	var columns []string
+
+	if update.Name != nil {
+		app.Name = *update.Name
+		columns = append(columns, "name")
+	}
+
+	if update.RedirectURIs != nil {
+		app.RedirectURIs = *update.RedirectURIs
+		columns = append(columns, "redirect_uris")
+	}
+
+	if update.AllowedScopes != nil {
+		app.AllowedScopes = *update.AllowedScopes
+		columns = append(columns, "allowed_scopes")
+	}
+
+	if update.RequireEmailVerification != nil {
+		app.RequireEmailVerification = *update.RequireEmailVerification
+		columns = append(columns, "require_email_verification")
+	}
+
+	if update.WebAuthnRPID != nil {
+		app.WebAuthnRPID = *update.WebAuthnRPID
+		columns = append(columns, "webauthn_rp_id")
+	}
+
+	if update.WebAuthnOrigins != nil {
+		app.WebAuthnOrigins = *update.WebAuthnOrigins
+		columns = append(columns, "webauthn_origins")
+	}
+
+	if update.SigningAlgorithm != nil {
+		app.SigningAlgorithm = *update.SigningAlgorithm
+		columns = append(columns, "signing_algorithm")
+	}
+
+	if update.PasswordPolicy != nil || update.ResetPasswordPolicy {
+		app.PasswordPolicy = update.PasswordPolicy
+		columns = append(columns, "password_policy")
+	}
+
+	if len(columns) == 0 {
+		if _, err := s.App(ctx, appID); err != nil {
+			return fmt.Errorf("%s %w", op, err)
+		}
+
+		return nil
+	}
+
+	// Updating from a struct rather than a map applies the json serializer
+	// of the list columns.
+	tx := s.db.WithContext(ctx).Model(&models.App{}).Where("id = ?", appID).Select(columns).Updates(&app)
+
+	if tx.Error != nil {
+		if IsUniqueConstraintError(tx.Error, UniqueConstraintApp) {
+			return fmt.Errorf("%s %w", op, storage.ErrAppExists)
+		}
+
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+// DeleteApp soft deletes the app and revokes the refresh tokens issued for
+// it. The name of a deleted app stays taken.
+func (s *Storage) DeleteApp(ctx context.Context, appID string) error {
+	const op = "storage.postgres.DeleteApp"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Delete(&models.App{}, "id = ?", appID)
+
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+
+		if deleted.RowsAffected == 0 {
+			return storage.ErrAppNotFound
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("app_id = ? AND revoked_at IS NULL", appID).
+			Update("revoked_at", time.Now()).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateAppSecret replaces the secret of the app and keeps the current one
+// as the previous secret until previousExpiresAt. A zero previousExpiresAt
+// drops the current secret instead.
+func (s *Storage) RotateAppSecret(
+	ctx context.Context,
+	appID string,
+	secretHash string,
+	secretCiphertext string,
+	previousExpiresAt time.Time,
+) error {
+	const op = "storage.postgres.RotateAppSecret"
+
+	columns := map[string]any{
+		"previous_secret_hash":       gorm.Expr("secret_hash"),
+		"previous_secret_ciphertext": gorm.Expr("secret_ciphertext"),
+		"previous_secret_expires_at": previousExpiresAt,
+		"secret_hash":                secretHash,
+		"secret_ciphertext":          secretCiphertext,
+	}
+
+	if previousExpiresAt.IsZero() {
+		columns["previous_secret_hash"] = ""
+		columns["previous_secret_ciphertext"] = ""
+		columns["previous_secret_expires_at"] = nil
+	}
+
+	tx := s.db.WithContext(ctx).Model(&models.App{}).Where("id = ?", appID).Updates(columns)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+// MigrateLegacyAppSecrets protects the plaintext secrets of apps created
+// before secrets were stored hashed and encrypted, soft deleted ones
+// included, and drops the plaintext columns. It does nothing once they are
+// gone.
+func (s *Storage) MigrateLegacyAppSecrets(ctx context.Context, protect func(app *models.App) error) error {
+	const op = "storage.postgres.MigrateLegacyAppSecrets"
+
+	db := s.db.WithContext(ctx)
+
+	if !db.Migrator().HasColumn(&models.App{}, "secret") {
+		return nil
+	}
+
+	hasPrevious := db.Migrator().HasColumn(&models.App{}, "previous_secret")
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		type legacyApp struct {
+			ID             string
+			Secret         string
+			PreviousSecret string
+		}
+
+		columns := []string{"id", "secret"}
+		if hasPrevious {
+			columns = append(columns, "previous_secret")
+		}
+
+		var rows []legacyApp
+
+		if err := tx.Table("apps").Select(columns).Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			app := models.App{ID: row.ID, Secret: row.Secret, PreviousSecret: row.PreviousSecret}
+
+			if err := protect(&app); err != nil {
+				return err
+			}
+
+			err := tx.Unscoped().Model(&models.App{}).Where("id = ?", app.ID).Updates(map[string]any{
+				"secret_hash":                app.SecretHash,
+				"secret_ciphertext":          app.SecretCiphertext,
+				"previous_secret_hash":       app.PreviousSecretHash,
+				"previous_secret_ciphertext": app.PreviousSecretCiphertext,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if hasPrevious {
+			if err := tx.Migrator().DropColumn(&models.App{}, "previous_secret"); err != nil {
+				return err
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.App{}, "secret")
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	const op = "storage.postgres.SaveRefreshToken"
+
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	tx := s.db.WithContext(ctx).Create(&token)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+func (s *Storage) RefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	const op = "storage.postgres.RefreshToken"
+
+	var token models.RefreshToken
+	tx := s.db.WithContext(ctx).First(&token, "token_hash = ?", tokenHash)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return models.RefreshToken{}, fmt.Errorf("%s %w", op, storage.ErrRefreshTokenNotFound)
+		}
+
+		return models.RefreshToken{}, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return token, nil
+}
+
+// UseRefreshToken marks the token as used. The update is conditional, so
+// when two requests race with the same token only one of them succeeds and
+// the other gets storage.ErrRefreshTokenUsed.
+func (s *Storage) UseRefreshToken(ctx context.Context, tokenID string) error {
+	const op = "storage.postgres.UseRefreshToken"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrRefreshTokenUsed)
+	}
+
+	return nil
+}
+
+func (s *Storage) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	const op = "storage.postgres.RevokeRefreshTokenFamily"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now())
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+func (s *Storage) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	const op = "storage.postgres.RevokeToken"
+
+	token := models.RevokedToken{JTI: jti, ExpiresAt: expiresAt}
+
+	tx := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&token)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+func (s *Storage) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	const op = "storage.postgres.IsTokenRevoked"
+
+	var count int64
+	tx := s.db.WithContext(ctx).Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count)
+
+	if tx.Error != nil {
+		return false, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return count > 0, nil
+}
+
+// DeleteExpiredRevokedTokens removes denylist entries of tokens that expired
+// before the given moment and returns the number of removed entries.
+func (s *Storage) DeleteExpiredRevokedTokens(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredRevokedTokens"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.RevokedToken{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+// RotateSigningKey retires the active key of the same algorithm and makes
+// key the active one.
+func (s *Storage) RotateSigningKey(ctx context.Context, key models.SigningKey) error {
+	const op = "storage.postgres.RotateSigningKey"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.SigningKey{}).
+			Where("algorithm = ? AND retired_at IS NULL", key.Algorithm).
+			Update("retired_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&key).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// MigrateLegacySigningKeys encrypts the private keys stored in plaintext
+// before keys were encrypted at rest and drops the plaintext column. It does
+// nothing once the column is gone.
+func (s *Storage) MigrateLegacySigningKeys(ctx context.Context, seal func(plaintext []byte) (string, error)) error {
+	const op = "storage.postgres.MigrateLegacySigningKeys"
+
+	db := s.db.WithContext(ctx)
+
+	if !db.Migrator().HasColumn(&models.SigningKey{}, "private_key") {
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		type legacyKey struct {
+			ID         string
+			PrivateKey []byte
+		}
+
+		var rows []legacyKey
+
+		if err := tx.Table("signing_keys").Select("id", "private_key").Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			ciphertext, err := seal(row.PrivateKey)
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&models.SigningKey{}).Where("id = ?", row.ID).
+				Update("private_key_ciphertext", ciphertext).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.SigningKey{}, "private_key")
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// SigningKeys returns active keys and keys retired after the given moment.
+func (s *Storage) SigningKeys(ctx context.Context, retiredAfter time.Time) ([]models.SigningKey, error) {
+	const op = "storage.postgres.SigningKeys"
+
+	var keys []models.SigningKey
+	tx := s.db.WithContext(ctx).
+		Where("retired_at IS NULL OR retired_at > ?", retiredAfter).
+		Order("created_at").
+		Find(&keys)
+
+	if tx.Error != nil {
+		return nil, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return keys, nil
+}
+
+func (s *Storage) DeleteSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteSigningKeys"
+
+	tx := s.db.WithContext(ctx).Where("retired_at < ?", retiredBefore).Delete(&models.SigningKey{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (s *Storage) SaveAuthorizationCode(ctx context.Context, code models.AuthorizationCode) error {
+	const op = "storage.postgres.SaveAuthorizationCode"
+
+	tx := s.db.WithContext(ctx).Create(&code)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+func (s *Storage) AuthorizationCode(ctx context.Context, codeHash string) (models.AuthorizationCode, error) {
+	const op = "storage.postgres.AuthorizationCode"
+
+	var code models.AuthorizationCode
+
+	if err := s.db.WithContext(ctx).First(&code, "code_hash = ?", codeHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AuthorizationCode{}, fmt.Errorf("%s %w", op, storage.ErrAuthorizationCodeNotFound)
+		}
+
+		return models.AuthorizationCode{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return code, nil
+}
+
+// UseAuthorizationCode marks the code of the app as used and records the id of
+// the access token issued for it. The update is conditional, so when two
+// requests race with the same code only one of them succeeds and the other
+// gets storage.ErrAuthorizationCodeUsed.
+func (s *Storage) UseAuthorizationCode(
+	ctx context.Context,
+	codeHash string,
+	appID string,
+	accessTokenID string,
+	now time.Time,
+) error {
+	const op = "storage.postgres.UseAuthorizationCode"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.AuthorizationCode{}).
+		Where("code_hash = ? AND app_id = ? AND used_at IS NULL", codeHash, appID).
+		Updates(map[string]any{"used_at": now, "access_token_id": accessTokenID})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrAuthorizationCodeUsed)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredAuthorizationCodes(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredAuthorizationCodes"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.AuthorizationCode{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (s *Storage) SavePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error {
+	const op = "storage.postgres.SavePasswordResetToken"
+
+	if err := s.db.WithContext(ctx).Create(&token).Error; err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// PasswordResetToken returns the reset token with the hash if it can still be
+// used, and storage.ErrPasswordResetTokenNotFound otherwise.
+func (s *Storage) PasswordResetToken(ctx context.Context, tokenHash string, now time.Time) (models.PasswordResetToken, error) {
+	const op = "storage.postgres.PasswordResetToken"
+
+	var token models.PasswordResetToken
+
+	err := s.db.WithContext(ctx).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&token).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.PasswordResetToken{}, fmt.Errorf("%s %w", op, storage.ErrPasswordResetTokenNotFound)
+		}
+
+		return models.PasswordResetToken{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return token, nil
+}
+
+// ResetPassword uses the reset token to replace the password of its user. In
+// the same transaction the other reset tokens of the user are used up and
+// their refresh tokens revoked. Used, expired and unknown tokens result in
+// storage.ErrPasswordResetTokenNotFound.
+func (s *Storage) ResetPassword(
+	ctx context.Context,
+	tokenHash string,
+	passHash []byte,
+	now time.Time,
+) (string, error) {
+	const op = "storage.postgres.ResetPassword"
+
+	var token models.PasswordResetToken
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrPasswordResetTokenNotFound
+			}
+
+			return err
+		}
+
+		err = tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error
+		if err != nil {
+			return err
+		}
+
+		return updatePassword(tx, token.UserID, passHash, now)
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return token.UserID, nil
+}
+
+func (s *Storage) DeleteExpiredPasswordResetTokens(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredPasswordResetTokens"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.PasswordResetToken{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (s *Storage) SaveEmailVerificationToken(ctx context.Context, token models.EmailVerificationToken) error {
+	const op = "storage.postgres.SaveEmailVerificationToken"
+
+	if err := s.db.WithContext(ctx).Create(&token).Error; err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// VerifyEmail uses the verification token to mark the email of its user as
+// verified and uses up the other verification tokens of the user. Used,
+// expired and unknown tokens, as well as tokens sent to an email the user no
+// longer has, result in storage.ErrEmailVerificationTokenNotFound.
+func (s *Storage) VerifyEmail(ctx context.Context, tokenHash string, now time.Time) (string, error) {
+	const op = "storage.postgres.VerifyEmail"
+
+	var token models.EmailVerificationToken
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrEmailVerificationTokenNotFound
+			}
+
+			return err
+		}
+
+		updated := tx.Model(&models.User{}).
+			Where("id = ? AND email = ?", token.UserID, token.Email).
+			Update("email_verified", true)
+
+		if updated.Error != nil {
+			return updated.Error
+		}
+
+		if updated.RowsAffected == 0 {
+			return storage.ErrEmailVerificationTokenNotFound
+		}
+
+		return tx.Model(&models.EmailVerificationToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return token.UserID, nil
+}
+
+func (s *Storage) DeleteExpiredEmailVerificationTokens(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredEmailVerificationTokens"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.EmailVerificationToken{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+// SaveTOTPSecret stores the sealed TOTP secret of a user who has not enabled
+// TOTP yet, replacing an unconfirmed one. Users with TOTP enabled result in
+// storage.ErrTOTPEnabled.
+func (s *Storage) SaveTOTPSecret(ctx context.Context, userID string, secretCiphertext string) error {
+	const op = "storage.postgres.SaveTOTPSecret"
+
+	tx := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND totp_enabled = ?", userID, false).
+		Updates(map[string]any{
+			"totp_secret_ciphertext": secretCiphertext,
+			"totp_last_step":         0,
+		})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrTOTPEnabled)
+	}
+
+	return nil
+}
+
+// EnableTOTP enables the stored TOTP secret of the user and records the step
+// of the code that confirmed it.
+func (s *Storage) EnableTOTP(ctx context.Context, userID string, step int64) error {
+	const op = "storage.postgres.EnableTOTP"
+
+	tx := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND totp_enabled = ?", userID, false).
+		Updates(map[string]any{
+			"totp_enabled":   true,
+			"totp_last_step": step,
+		})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrTOTPEnabled)
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the step of an accepted code. Steps that are not later
+// than the last recorded one result in storage.ErrTOTPCodeUsed, so that a
+// code can not be replayed within its validity window.
+func (s *Storage) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	const op = "storage.postgres.UseTOTPStep"
+
+	tx := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrTOTPCodeUsed)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces the recovery codes of the user, used or
+// not, with the given ones.
+func (s *Storage) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	const op = "storage.postgres.ReplaceRecoveryCodes"
+
+	codes := make([]models.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, models.RecoveryCode{CodeHash: hash, UserID: userID})
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. Used
+// and unknown codes result in storage.ErrRecoveryCodeNotFound.
+func (s *Storage) UseRecoveryCode(ctx context.Context, userID string, codeHash string, now time.Time) error {
+	const op = "storage.postgres.UseRecoveryCode"
+
+	tx := s.db.WithContext(ctx).Model(&models.RecoveryCode{}).
+		Where("code_hash = ? AND user_id = ? AND used_at IS NULL", codeHash, userID).
+		Update("used_at", now)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrRecoveryCodeNotFound)
+	}
+
+	return nil
+}
+
+// RecoveryCodesRemaining returns the number of unused recovery codes of the
+// user.
+func (s *Storage) RecoveryCodesRemaining(ctx context.Context, userID string) (int, error) {
+	const op = "storage.postgres.RecoveryCodesRemaining"
+
+	var count int64
+
+	err := s.db.WithContext(ctx).Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	return int(count), nil
+}
+
+func (s *Storage) SaveMFAChallenge(ctx context.Context, challenge models.MFAChallenge) error {
+	const op = "storage.postgres.SaveMFAChallenge"
+
+	if err := s.db.WithContext(ctx).Create(&challenge).Error; err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// MFAChallenge returns a pending challenge. Used, expired and unknown
+// challenges result in storage.ErrMFAChallengeNotFound.
+func (s *Storage) MFAChallenge(ctx context.Context, idHash string, now time.Time) (models.MFAChallenge, error) {
+	const op = "storage.postgres.MFAChallenge"
+
+	var challenge models.MFAChallenge
+
+	err := s.db.WithContext(ctx).
+		Where("id_hash = ? AND used_at IS NULL AND expires_at > ?", idHash, now).
+		First(&challenge).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return challenge, fmt.Errorf("%s %w", op, storage.ErrMFAChallengeNotFound)
+		}
+
+		return challenge, fmt.Errorf("%s %w", op, err)
+	}
+
+	return challenge, nil
+}
+
+// TakeMFAChallengeAttempt counts a code entered for the challenge before it
+// is checked. A challenge that was used or has maxAttempts attempts already
+// results in storage.ErrMFAChallengeNotFound, so that concurrent attempts can
+// not get past the limit.
+func (s *Storage) TakeMFAChallengeAttempt(ctx context.Context, idHash string, maxAttempts int) error {
+	const op = "storage.postgres.TakeMFAChallengeAttempt"
+
+	tx := s.db.WithContext(ctx).Model(&models.MFAChallenge{}).
+		Where("id_hash = ? AND used_at IS NULL AND attempts < ?", idHash, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrMFAChallengeNotFound)
+	}
+
+	return nil
+}
+
+// UseMFAChallenge marks the challenge as completed. A challenge that was
+// already used results in storage.ErrMFAChallengeNotFound, so that only one
+// of concurrent completions issues tokens.
+func (s *Storage) UseMFAChallenge(ctx context.Context, idHash string, now time.Time) error {
+	const op = "storage.postgres.UseMFAChallenge"
+
+	tx := s.db.WithContext(ctx).Model(&models.MFAChallenge{}).
+		Where("id_hash = ? AND used_at IS NULL", idHash).
+		Update("used_at", now)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrMFAChallengeNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredMFAChallenges(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredMFAChallenges"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.MFAChallenge{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (s *Storage) SaveWebAuthnSession(ctx context.Context, session models.WebAuthnSession) error {
+	const op = "storage.postgres.SaveWebAuthnSession"
+
+	if err := s.db.WithContext(ctx).Create(&session).Error; err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// UseWebAuthnSession marks a pending session of the ceremony as used and
+// returns it. Used, expired and unknown sessions, and sessions of the other
+// ceremony, result in storage.ErrWebAuthnSessionNotFound.
+func (s *Storage) UseWebAuthnSession(
+	ctx context.Context,
+	idHash string,
+	ceremony string,
+	now time.Time,
+) (models.WebAuthnSession, error) {
+	const op = "storage.postgres.UseWebAuthnSession"
+
+	var session models.WebAuthnSession
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id_hash = ? AND ceremony = ? AND used_at IS NULL AND expires_at > ?", idHash, ceremony, now).
+			First(&session).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrWebAuthnSessionNotFound
+			}
+
+			return err
+		}
+
+		return tx.Model(&session).Update("used_at", now).Error
+	})
+
+	if err != nil {
+		return session, fmt.Errorf("%s %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) DeleteExpiredWebAuthnSessions(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredWebAuthnSessions"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.WebAuthnSession{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (s *Storage) SaveWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) error {
+	const op = "storage.postgres.SaveWebAuthnCredential"
+
+	if err := s.db.WithContext(ctx).Create(&credential).Error; err != nil {
+		if IsUniqueConstraintError(err, UniqueConstraintWebAuthnCredential) {
+			return fmt.Errorf("%s %w", op, storage.ErrWebAuthnCredentialExists)
+		}
+
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// WebAuthnCredential returns a credential by its base64url encoded ID.
+func (s *Storage) WebAuthnCredential(ctx context.Context, id string) (models.WebAuthnCredential, error) {
+	const op = "storage.postgres.WebAuthnCredential"
+
+	var credential models.WebAuthnCredential
+
+	if err := s.db.WithContext(ctx).First(&credential, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return credential, fmt.Errorf("%s %w", op, storage.ErrWebAuthnCredentialNotFound)
+		}
+
+		return credential, fmt.Errorf("%s %w", op, err)
+	}
+
+	return credential, nil
+}
+
+// WebAuthnCredentials returns the credentials of the user for a relying
+// party.
+func (s *Storage) WebAuthnCredentials(ctx context.Context, userID string, rpID string) ([]models.WebAuthnCredential, error) {
+	const op = "storage.postgres.WebAuthnCredentials"
+
+	var credentials []models.WebAuthnCredential
+
+	err := s.db.WithContext(ctx).
+		Where("user_id = ? AND rp_id = ?", userID, rpID).
+		Order("created_at").
+		Find(&credentials).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return credentials, nil
+}
+
+// UseWebAuthnCredential records a sign-in with the credential. The counter is
+// only replaced if it is still previousSignCount, so that of two concurrent
+// sign-ins with the same counter value only one succeeds; the other results
+// in storage.ErrWebAuthnCredentialNotFound.
+func (s *Storage) UseWebAuthnCredential(
+	ctx context.Context,
+	id string,
+	previousSignCount uint32,
+	signCount uint32,
+	now time.Time,
+) error {
+	const op = "storage.postgres.UseWebAuthnCredential"
+
+	tx := s.db.WithContext(ctx).Model(&models.WebAuthnCredential{}).
+		Where("id = ? AND sign_count = ?", id, previousSignCount).
+		Updates(map[string]any{
+			"sign_count":   signCount,
+			"last_used_at": now,
+		})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrWebAuthnCredentialNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveDeviceCode(ctx context.Context, code models.DeviceCode) error {
+	const op = "storage.postgres.SaveDeviceCode"
+
+	if err := s.db.WithContext(ctx).Create(&code).Error; err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// DeviceCodeByUserCode returns the pending device code with the given user
+// code that has not expired yet.
+func (s *Storage) DeviceCodeByUserCode(ctx context.Context, userCode string, now time.Time) (models.DeviceCode, error) {
+	const op = "storage.postgres.DeviceCodeByUserCode"
+
+	var code models.DeviceCode
+
+	err := s.db.WithContext(ctx).
+		Where("user_code = ? AND status = ? AND expires_at > ?", userCode, models.DeviceCodePending, now).
+		First(&code).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DeviceCode{}, fmt.Errorf("%s %w", op, storage.ErrDeviceCodeNotFound)
+		}
+
+		return models.DeviceCode{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return code, nil
+}
+
+// DecideDeviceCode records the decision of the user on a pending device code
+// that has not expired yet.
+func (s *Storage) DecideDeviceCode(
+	ctx context.Context,
+	userCode string,
+	userID string,
+	status string,
+	now time.Time,
+) error {
+	const op = "storage.postgres.DecideDeviceCode"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.DeviceCode{}).
+		Where("user_code = ? AND status = ? AND expires_at > ?", userCode, models.DeviceCodePending, now).
+		Updates(map[string]interface{}{"status": status, "user_id": userID})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrDeviceCodeNotFound)
+	}
+
+	return nil
+}
+
+// PollDeviceCode records a poll of the device of the app and returns the code
+// as it was before the poll. Codes of other apps are not found.
+func (s *Storage) PollDeviceCode(
+	ctx context.Context,
+	deviceCodeHash string,
+	appID string,
+	now time.Time,
+) (models.DeviceCode, error) {
+	const op = "storage.postgres.PollDeviceCode"
+
+	var code models.DeviceCode
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&code, "device_code_hash = ? AND app_id = ?", deviceCodeHash, appID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrDeviceCodeNotFound
+			}
+
+			return err
+		}
+
+		return tx.Model(&models.DeviceCode{}).
+			Where("device_code_hash = ?", deviceCodeHash).
+			Update("last_polled_at", now).Error
+	})
+
+	if err != nil {
+		return models.DeviceCode{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return code, nil
+}
+
+// UseDeviceCode marks the approved device code of the app as used. The update
+// is conditional, so when two polls race only one of them succeeds and the
+// other gets storage.ErrDeviceCodeUsed.
+func (s *Storage) UseDeviceCode(ctx context.Context, deviceCodeHash string, appID string, now time.Time) error {
+	const op = "storage.postgres.UseDeviceCode"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.DeviceCode{}).
+		Where(
+			"device_code_hash = ? AND app_id = ? AND status = ? AND used_at IS NULL",
+			deviceCodeHash, appID, models.DeviceCodeApproved,
+		).
+		Update("used_at", now)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrDeviceCodeUsed)
+	}
+
+	return nil
+}
+
+// SlowDownDeviceCode sets the minimal polling interval of the device code.
+func (s *Storage) SlowDownDeviceCode(ctx context.Context, deviceCodeHash string, interval time.Duration) error {
+	const op = "storage.postgres.SlowDownDeviceCode"
+
+	tx := s.db.WithContext(ctx).
+		Model(&models.DeviceCode{}).
+		Where("device_code_hash = ?", deviceCodeHash).
+		Update("interval", interval)
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteExpiredDeviceCodes(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredDeviceCodes"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.DeviceCode{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+// SaveRole creates the role together with its permissions. Permissions that
+// the app does not have yet are created.
+func (s *Storage) SaveRole(ctx context.Context, role models.Role) (string, error) {
+	const op = "storage.postgres.SaveRole"
+
+	role.ID = uuid.New().String()
+	permissions := role.Permissions
+	role.Permissions = nil
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if err := addRolePermission(tx, role, permission.Name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if IsUniqueConstraintError(err, UniqueConstraintRole) {
+			return "", fmt.Errorf("%s %w", op, storage.ErrRoleExists)
+		}
+
+		return "", fmt.Errorf("%s %w", op, err)
+	}
+
+	return role.ID, nil
+}
+
+func (s *Storage) Role(ctx context.Context, roleID string) (models.Role, error) {
+	const op = "storage.postgres.Role"
+
+	var role models.Role
+	tx := s.db.WithContext(ctx).First(&role, "id = ?", roleID)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return models.Role{}, fmt.Errorf("%s %w", op, storage.ErrRoleNotFound)
+		}
+
+		return models.Role{}, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return role, nil
+}
+
+func (s *Storage) AddRolePermission(ctx context.Context, roleID string, permission string) error {
+	const op = "storage.postgres.AddRolePermission"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var role models.Role
+
+		if err := tx.First(&role, "id = ?", roleID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrRoleNotFound
+			}
+
+			return err
+		}
+
+		return addRolePermission(tx, role, permission)
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+func addRolePermission(tx *gorm.DB, role models.Role, name string) error {
+	var permission models.Permission
+
+	err := tx.
+		Where(models.Permission{AppID: role.AppID, Name: name}).
+		Attrs(models.Permission{ID: uuid.New().String()}).
+		FirstOrCreate(&permission).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&role).Association("Permissions").Append(&permission)
+}
+
+func (s *Storage) AssignRole(ctx context.Context, userID string, roleID string) error {
+	const op = "storage.postgres.AssignRole"
+
+	tx := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserRole{UserID: userID, RoleID: roleID})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return nil
+}
+
+// UserAccess returns the names of the roles the user has in the app and of
+// the permissions granted by them.
+func (s *Storage) UserAccess(ctx context.Context, userID string, appID string) ([]string, []string, error) {
+	const op = "storage.postgres.UserAccess"
+
+	var roles, permissions []string
+
+	err := s.db.WithContext(ctx).
+		Model(&models.Role{}).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ? AND roles.app_id = ?", userID, appID).
+		Order("roles.name").
+		Pluck("roles.name", &roles).Error
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	err = s.db.WithContext(ctx).
+		Model(&models.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ? AND permissions.app_id = ?", userID, appID).
+		Order("permissions.name").
+		Pluck("permissions.name", &permissions).Error
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return roles, permissions, nil
+}
+
+func (s *Storage) HasPermission(ctx context.Context, userID string, appID string, permission string) (bool, error) {
+	const op = "storage.postgres.HasPermission"
+
+	var count int64
+
+	err := s.db.WithContext(ctx).
+		Model(&models.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ? AND permissions.app_id = ? AND permissions.name = ?", userID, appID, permission).
+		Count(&count).Error
+
+	if err != nil {
+		return false, fmt.Errorf("%s %w", op, err)
+	}
+
+	return count > 0, nil
+}
+
+// SaveEmailLogin saves a new email login. Pending logins of the user to the
+// same app are superseded, so only the code and link sent last work.
+func (s *Storage) SaveEmailLogin(ctx context.Context, login models.EmailLogin) error {
+	const op = "storage.postgres.SaveEmailLogin"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.EmailLogin{}).
+			Where("user_id = ? AND app_id = ? AND used_at IS NULL", login.UserID, login.AppID).
+			Update("used_at", login.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&login).Error
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	return nil
+}
+
+// UseEmailLoginCode completes the pending login of the user to the app if
+// codeHash matches and returns it. A wrong code is counted and results in
+// storage.ErrEmailLoginCodeMismatch. Logins that are used, expired or have
+// maxAttempts wrong codes result in storage.ErrEmailLoginNotFound.
+func (s *Storage) UseEmailLoginCode(
+	ctx context.Context,
+	userID string,
+	appID string,
+	codeHash string,
+	maxAttempts int,
+	now time.Time,
+) (models.EmailLogin, error) {
+	const op = "storage.postgres.UseEmailLoginCode"
+
+	var login models.EmailLogin
+	var mismatch bool
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(
+				"user_id = ? AND app_id = ? AND used_at IS NULL AND expires_at > ? AND attempts < ?",
+				userID, appID, now, maxAttempts,
+			).
+			Order("created_at DESC").
+			First(&login).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrEmailLoginNotFound
+			}
+
+			return err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(login.CodeHash), []byte(codeHash)) != 1 {
+			mismatch = true
+
+			return tx.Model(&login).Update("attempts", gorm.Expr("attempts + 1")).Error
+		}
+
+		return tx.Model(&login).Update("used_at", now).Error
+	})
+
+	if err != nil {
+		return models.EmailLogin{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	if mismatch {
+		return models.EmailLogin{}, fmt.Errorf("%s %w", op, storage.ErrEmailLoginCodeMismatch)
+	}
+
+	return login, nil
+}
+
+// UseEmailLoginLink completes the pending login with the ID hash carried by
+// a magic link and returns it. Used, expired and unknown logins result in
+// storage.ErrEmailLoginNotFound.
+func (s *Storage) UseEmailLoginLink(ctx context.Context, idHash string, now time.Time) (models.EmailLogin, error) {
+	const op = "storage.postgres.UseEmailLoginLink"
+
+	var login models.EmailLogin
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id_hash = ? AND used_at IS NULL AND expires_at > ?", idHash, now).
+			First(&login).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return storage.ErrEmailLoginNotFound
+			}
+
+			return err
+		}
+
+		return tx.Model(&login).Update("used_at", now).Error
+	})
+
+	if err != nil {
+		return models.EmailLogin{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return login, nil
+}
+
+func (s *Storage) DeleteExpiredEmailLogins(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredEmailLogins"
+
+	tx := s.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.EmailLogin{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+// LoginThrottles returns the throttles of the keys that have any.
+func (s *Storage) LoginThrottles(ctx context.Context, keys []string) ([]models.LoginThrottle, error) {
+	const op = "storage.postgres.LoginThrottles"
+
+	var throttles []models.LoginThrottle
+
+	if err := s.db.WithContext(ctx).Where("key IN ?", keys).Find(&throttles).Error; err != nil {
+		return nil, fmt.Errorf("%s %w", op, err)
+	}
+
+	return throttles, nil
+}
+
+// RecordLoginFailure counts a failed login for the key and returns the
+// updated throttle. Failures are counted afresh once the last one is older
+// than window. The key is locked for lockoutDuration when the count reaches
+// lockoutThreshold, which 0 disables.
+func (s *Storage) RecordLoginFailure(
+	ctx context.Context,
+	key string,
+	now time.Time,
+	window time.Duration,
+	lockoutThreshold int,
+	lockoutDuration time.Duration,
+) (models.LoginThrottle, error) {
+	const op = "storage.postgres.RecordLoginFailure"
+
+	var throttle models.LoginThrottle
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.LoginThrottle{Key: key, LastFailureAt: now}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&throttle).Error
+		if err != nil {
+			return err
+		}
+
+		if throttle.LastFailureAt.Before(now.Add(-window)) {
+			throttle.Failures = 0
+		}
+
+		throttle.Failures++
+		throttle.LastFailureAt = now
+
+		if lockoutThreshold > 0 && throttle.Failures >= lockoutThreshold {
+			lockedUntil := now.Add(lockoutDuration)
+			throttle.LockedUntil = &lockedUntil
+		}
+
+		return tx.Save(&throttle).Error
+	})
+
+	if err != nil {
+		return models.LoginThrottle{}, fmt.Errorf("%s %w", op, err)
+	}
+
+	return throttle, nil
+}
+
+// ThrottleCall counts a call under key, allowing limit calls within window
+// of each other and at least interval apart. Refused calls are not counted
+// and result in how long until the next call is allowed.
+func (s *Storage) ThrottleCall(
+	ctx context.Context,
+	key string,
+	now time.Time,
+	window time.Duration,
+	interval time.Duration,
+	limit int,
+) (time.Duration, error) {
+	const op = "storage.postgres.ThrottleCall"
+
+	var retryAfter time.Duration
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.LoginThrottle{Key: key, LastFailureAt: now}).Error
+		if err != nil {
+			return err
+		}
+
+		var throttle models.LoginThrottle
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&throttle).Error
+		if err != nil {
+			return err
+		}
+
+		if throttle.LastFailureAt.Before(now.Add(-window)) {
+			throttle.Failures = 0
+		}
+
+		if throttle.Failures > 0 {
+			if next := throttle.LastFailureAt.Add(interval); next.After(now) {
+				retryAfter = next.Sub(now)
+
+				return nil
+			}
+
+			if throttle.Failures >= limit {
+				retryAfter = throttle.LastFailureAt.Add(window).Sub(now)
+
+				return nil
+			}
+		}
+
+		throttle.Failures++
+		throttle.LastFailureAt = now
+
+		return tx.Save(&throttle).Error
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	return retryAfter, nil
+}
+
+// ResetLoginFailures forgets the failed logins of the key and lifts its
+// lockout. Keys without failures result in storage.ErrLoginThrottleNotFound.
+func (s *Storage) ResetLoginFailures(ctx context.Context, key string) error {
+	const op = "storage.postgres.ResetLoginFailures"
+
+	tx := s.db.WithContext(ctx).Where("key = ?", key).Delete(&models.LoginThrottle{})
+
+	if tx.Error != nil {
+		return fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("%s %w", op, storage.ErrLoginThrottleNotFound)
+	}
+
+	return nil
+}
+
+// DeleteStaleLoginThrottles deletes the throttles without failures since
+// before that are not locked anymore.
+func (s *Storage) DeleteStaleLoginThrottles(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteStaleLoginThrottles"
+
+	tx := s.db.WithContext(ctx).
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, before).
+		Delete(&models.LoginThrottle{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
+}
+
+// TakeRateLimitToken takes a token from the bucket of the key, see
+// ratelimit.Store. The bucket is locked while it is updated, so that all the
+// replicas using the database share it.
+func (s *Storage) TakeRateLimitToken(
+	ctx context.Context,
+	key string,
+	limit ratelimit.Limit,
+	now time.Time,
+) (bool, time.Duration, error) {
+	const op = "storage.postgres.TakeRateLimitToken"
+
+	var ok bool
+	var retryAfter time.Duration
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.RateLimitBucket{Key: key, Tokens: float64(limit.Burst), RefilledAt: now}).Error
+		if err != nil {
+			return err
+		}
+
+		var bucket models.RateLimitBucket
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&bucket).Error
+		if err != nil {
+			return err
+		}
+
+		state := ratelimit.Bucket{Tokens: bucket.Tokens, RefilledAt: bucket.RefilledAt}
+		ok, retryAfter = state.Take(limit, now)
+
+		return tx.Model(&bucket).Updates(map[string]any{
+			"tokens":      state.Tokens,
+			"refilled_at": state.RefilledAt,
+		}).Error
+	})
+
+	if err != nil {
+		return false, 0, fmt.Errorf("%s %w", op, err)
+	}
+
+	return ok, retryAfter, nil
+}
+
+// DeleteIdleRateLimitBuckets deletes the buckets last used before, which are
+// full again if before is at least the fill time of the longest limit ago.
+func (s *Storage) DeleteIdleRateLimitBuckets(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteIdleRateLimitBuckets"
+
+	tx := s.db.WithContext(ctx).Where("refilled_at < ?", before).Delete(&models.RateLimitBucket{})
+
+	if tx.Error != nil {
+		return 0, fmt.Errorf("%s %w", op, tx.Error)
+	}
+
+	return tx.RowsAffected, nil
 }
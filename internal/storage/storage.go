@@ -7,4 +7,38 @@ var (
 	ErrAppExists    = errors.New("app already exists")
 	ErrUserNotFound = errors.New("user not found")
 	ErrAppNotFound  = errors.New("app not found")
+
+	ErrMembershipExists = errors.New("user is already a member of the app")
+
+	ErrRoleExists   = errors.New("role already exists")
+	ErrRoleNotFound = errors.New("role not found")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenUsed     = errors.New("refresh token already used")
+
+	ErrAuthorizationCodeNotFound = errors.New("authorization code not found")
+	ErrAuthorizationCodeUsed     = errors.New("authorization code already used")
+
+	ErrDeviceCodeNotFound = errors.New("device code not found")
+	ErrDeviceCodeUsed     = errors.New("device code already used")
+
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+
+	ErrTOTPEnabled  = errors.New("totp is already enabled")
+	ErrTOTPCodeUsed = errors.New("totp code already used")
+
+	ErrMFAChallengeNotFound = errors.New("mfa challenge not found")
+
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+
+	ErrWebAuthnSessionNotFound    = errors.New("webauthn session not found")
+	ErrWebAuthnCredentialExists   = errors.New("webauthn credential already exists")
+	ErrWebAuthnCredentialNotFound = errors.New("webauthn credential not found")
+
+	ErrEmailLoginNotFound     = errors.New("email login not found")
+	ErrEmailLoginCodeMismatch = errors.New("email login code does not match")
+
+	ErrLoginThrottleNotFound = errors.New("login throttle not found")
 )
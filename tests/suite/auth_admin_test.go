@@ -0,0 +1,118 @@
+package suite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminRPC_Authorization(t *testing.T) {
+	ctx, st := New(t)
+
+	request := &ssov1.RegisterAppRequest{
+		Name:   gofakeit.Name(),
+		Secret: randomFakePassword(),
+	}
+
+	_, err := st.AuthClient.RegisterApp(ctx, request)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	invalidCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+gofakeit.LetterN(32))
+
+	_, err = st.AuthClient.RegisterApp(invalidCtx, request)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	_, err = st.AuthClient.RegisterApp(userCtx, request)
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = st.AuthClient.IsAdmin(userCtx, &ssov1.IsAdminRequest{UserUuid: gofakeit.UUID()})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), request)
+	require.NoError(t, err)
+	assert.NotEmpty(t, registerAppResponse.GetAppUuid())
+}
+
+func TestAdminRPC_TokenSignedWithAppSecret(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	adminToken, _ := metadata.FromOutgoingContext(adminCtx)
+	adminClaims := jwt.MapClaims{}
+	_, _, err := new(jwt.Parser).ParseUnverified(strings.TrimPrefix(adminToken.Get("authorization")[0], "Bearer "), adminClaims)
+	require.NoError(t, err)
+
+	appSecret := randomFakePassword()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminCtx, &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           appSecret,
+		SigningAlgorithm: "HS256",
+	})
+	require.NoError(t, err)
+
+	// The owner of an HS256 app knows its secret and can sign a token for
+	// any user, the admin included.
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti":    gofakeit.UUID(),
+		"uid":    adminClaims["uid"],
+		"email":  st.Cfg.Admin.Email,
+		"app_id": registerAppResponse.GetAppUuid(),
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(appSecret))
+	require.NoError(t, err)
+
+	forgedCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+forged)
+
+	_, err = st.AuthClient.ListApps(forgedCtx, &ssov1.ListAppsRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = st.AuthClient.UserInfo(forgedCtx, &ssov1.UserInfoRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// adminContext returns ctx authorized with an access token of the admin
+// created on startup from the admin section of the config.
+func adminContext(ctx context.Context, st *Suite) context.Context {
+	st.Helper()
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    st.Cfg.Admin.Email,
+		Password: st.Cfg.Admin.Password,
+		AppUuid:  st.Cfg.Admin.AppID,
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, loginResponse.GetToken())
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+}
@@ -0,0 +1,166 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAppManagement_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID, _ := registerOAuthApp(ctx, st)
+
+	getResponse, err := st.AuthClient.GetApp(adminCtx, &ssov1.GetAppRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+	assert.Equal(t, appUUID, getResponse.GetApp().GetAppUuid())
+	assert.Equal(t, []string{redirectURI}, getResponse.GetApp().GetRedirectUris())
+
+	newName := gofakeit.Name()
+	newRedirectURI := "https://client.example.com/other"
+
+	updateResponse, err := st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:      appUUID,
+		Name:         &newName,
+		RedirectUris: &ssov1.StringList{Values: []string{newRedirectURI}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, newName, updateResponse.GetApp().GetName())
+	assert.Equal(t, []string{newRedirectURI}, updateResponse.GetApp().GetRedirectUris())
+	assert.Empty(t, updateResponse.GetApp().GetAllowedScopes())
+
+	_, err = st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:      appUUID,
+		RedirectUris: &ssov1.StringList{Values: []string{"not a uri"}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	firstPage, err := st.AuthClient.ListApps(adminCtx, &ssov1.ListAppsRequest{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, firstPage.GetApps(), 1)
+	require.NotEmpty(t, firstPage.GetNextPageToken())
+
+	secondPage, err := st.AuthClient.ListApps(adminCtx, &ssov1.ListAppsRequest{
+		PageSize:  1,
+		PageToken: firstPage.GetNextPageToken(),
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage.GetApps(), 1)
+	assert.NotEqual(t, firstPage.GetApps()[0].GetAppUuid(), secondPage.GetApps()[0].GetAppUuid())
+}
+
+func TestDeleteApp(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.DeleteApp(adminCtx, &ssov1.DeleteAppRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+
+	_, err = st.AuthClient.GetApp(adminCtx, &ssov1.GetAppRequest{AppUuid: appUUID})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRotateAppSecret_GracePeriod(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID, oldSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	rotateResponse, err := st.AuthClient.RotateAppSecret(adminCtx, &ssov1.RotateAppSecretRequest{
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rotateResponse.GetSecret())
+	assert.NotEqual(t, oldSecret, rotateResponse.GetSecret())
+	assert.Positive(t, rotateResponse.GetPreviousSecretExpiresAt())
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.True(t, introspectResponse.GetActive())
+
+	for _, secret := range []string{oldSecret, rotateResponse.GetSecret()} {
+		_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+			GrantType:    "client_credentials",
+			ClientId:     appUUID,
+			ClientSecret: secret,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     appUUID,
+		ClientSecret: randomFakePassword(),
+	})
+	requireOAuthError(t, err, "invalid_client")
+}
+
+func TestRotateAppSecret_RevokePrevious(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID, oldSecret := registerOAuthApp(ctx, st)
+
+	// A rotation with a grace period leaves two secrets; revoking drops both
+	// of the old ones.
+	_, err := st.AuthClient.RotateAppSecret(adminCtx, &ssov1.RotateAppSecretRequest{
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	rotateResponse, err := st.AuthClient.RotateAppSecret(adminCtx, &ssov1.RotateAppSecretRequest{
+		AppUuid:        appUUID,
+		RevokePrevious: true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rotateResponse.GetSecret())
+	assert.Zero(t, rotateResponse.GetPreviousSecretExpiresAt())
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     appUUID,
+		ClientSecret: oldSecret,
+	})
+	requireOAuthError(t, err, "invalid_client")
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     appUUID,
+		ClientSecret: rotateResponse.GetSecret(),
+	})
+	require.NoError(t, err)
+
+	getAppResponse, err := st.AuthClient.GetApp(adminCtx, &ssov1.GetAppRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+	assert.Zero(t, getAppResponse.GetApp().GetPreviousSecretExpiresAt())
+}
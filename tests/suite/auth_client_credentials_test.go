@@ -0,0 +1,121 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientCredentials_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appSecret := randomFakePassword()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:          gofakeit.Name(),
+		Secret:        appSecret,
+		AllowedScopes: []string{"orders:read", "orders:write"},
+	})
+	require.NoError(t, err)
+
+	appUUID := registerAppResponse.GetAppUuid()
+
+	tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		Scope:        "orders:read",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+	assert.Empty(t, tokenResponse.GetRefreshToken())
+	assert.Empty(t, tokenResponse.GetIdToken())
+	assert.Equal(t, "orders:read", tokenResponse.GetScope())
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   tokenResponse.GetAccessToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.True(t, introspectResponse.GetActive())
+	assert.Empty(t, introspectResponse.GetUid())
+	assert.Equal(t, appUUID, introspectResponse.GetClientId())
+	assert.Equal(t, "orders:read", introspectResponse.GetScope())
+
+	userInfoCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tokenResponse.GetAccessToken())
+
+	_, err = st.AuthClient.UserInfo(userInfoCtx, &ssov1.UserInfoRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	tokenResponse, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "orders:read orders:write", tokenResponse.GetScope())
+}
+
+func TestClientCredentials_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appSecret := randomFakePassword()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:          gofakeit.Name(),
+		Secret:        appSecret,
+		AllowedScopes: []string{"orders:read"},
+	})
+	require.NoError(t, err)
+
+	appUUID := registerAppResponse.GetAppUuid()
+
+	tests := []struct {
+		name     string
+		request  *ssov1.TokenRequest
+		expected string
+	}{
+		{
+			name: "Wrong secret",
+			request: &ssov1.TokenRequest{
+				GrantType:    "client_credentials",
+				ClientId:     appUUID,
+				ClientSecret: randomFakePassword(),
+			},
+			expected: "invalid_client",
+		},
+		{
+			name: "Without secret",
+			request: &ssov1.TokenRequest{
+				GrantType: "client_credentials",
+				ClientId:  appUUID,
+			},
+			expected: "invalid_client",
+		},
+		{
+			name: "Scope not allowed",
+			request: &ssov1.TokenRequest{
+				GrantType:    "client_credentials",
+				ClientId:     appUUID,
+				ClientSecret: appSecret,
+				Scope:        "orders:read orders:write",
+			},
+			expected: "invalid_scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.AuthClient.Token(ctx, tt.request)
+			requireOAuthError(t, err, tt.expected)
+		})
+	}
+}
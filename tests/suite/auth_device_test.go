@@ -0,0 +1,175 @@
+package suite
+
+import (
+	"strings"
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+func TestDeviceFlow_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerPublicApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	deviceResponse, err := st.AuthClient.DeviceAuthorize(ctx, &ssov1.DeviceAuthorizeRequest{
+		ClientId: appUUID,
+		Scope:    "openid",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, deviceResponse.GetDeviceCode())
+	require.NotEmpty(t, deviceResponse.GetUserCode())
+	assert.NotEmpty(t, deviceResponse.GetVerificationUri())
+	assert.Contains(t, deviceResponse.GetVerificationUriComplete(), deviceResponse.GetVerificationUri())
+	assert.Positive(t, deviceResponse.GetExpiresIn())
+	assert.Positive(t, deviceResponse.GetInterval())
+
+	tokenRequest := &ssov1.TokenRequest{
+		GrantType:  deviceCodeGrantType,
+		DeviceCode: deviceResponse.GetDeviceCode(),
+		ClientId:   appUUID,
+	}
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "authorization_pending")
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "slow_down")
+
+	verifyCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	// User codes are accepted regardless of case and separators.
+	userCode := strings.ToLower(strings.ReplaceAll(deviceResponse.GetUserCode(), "-", ""))
+
+	_, err = st.AuthClient.VerifyDevice(verifyCtx, &ssov1.VerifyDeviceRequest{
+		UserCode: userCode,
+		Approve:  true,
+	})
+	require.NoError(t, err)
+
+	tokenResponse, err := st.AuthClient.Token(ctx, tokenRequest)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+	assert.NotEmpty(t, tokenResponse.GetRefreshToken())
+	assert.Equal(t, "openid", tokenResponse.GetScope())
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "invalid_grant")
+
+	_, err = st.AuthClient.VerifyDevice(verifyCtx, &ssov1.VerifyDeviceRequest{
+		UserCode: userCode,
+		Approve:  true,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeviceFlow_Denied(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerPublicApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	deviceResponse, err := st.AuthClient.DeviceAuthorize(ctx, &ssov1.DeviceAuthorizeRequest{
+		ClientId: appUUID,
+	})
+	require.NoError(t, err)
+
+	verifyCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	_, err = st.AuthClient.VerifyDevice(verifyCtx, &ssov1.VerifyDeviceRequest{
+		UserCode: deviceResponse.GetUserCode(),
+		Approve:  false,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:  deviceCodeGrantType,
+		DeviceCode: deviceResponse.GetDeviceCode(),
+		ClientId:   appUUID,
+	})
+	requireOAuthError(t, err, "access_denied")
+}
+
+func TestDeviceFlow_ClientChecks(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerPublicApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	otherAppUUID, otherAppSecret := registerOAuthApp(ctx, st)
+
+	// A confidential client must present its secret.
+	_, err := st.AuthClient.DeviceAuthorize(ctx, &ssov1.DeviceAuthorizeRequest{
+		ClientId: otherAppUUID,
+	})
+	requireOAuthError(t, err, "invalid_client")
+
+	deviceResponse, err := st.AuthClient.DeviceAuthorize(ctx, &ssov1.DeviceAuthorizeRequest{
+		ClientId: appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyDevice(loginContext(ctx, t, st, email, pass, appUUID), &ssov1.VerifyDeviceRequest{
+		UserCode: deviceResponse.GetUserCode(),
+		Approve:  true,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:  deviceCodeGrantType,
+		DeviceCode: deviceResponse.GetDeviceCode(),
+		ClientId:   otherAppUUID,
+	})
+	requireOAuthError(t, err, "invalid_client")
+
+	// Another client can not redeem the code, nor burn it.
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    deviceCodeGrantType,
+		DeviceCode:   deviceResponse.GetDeviceCode(),
+		ClientId:     otherAppUUID,
+		ClientSecret: otherAppSecret,
+	})
+	requireOAuthError(t, err, "invalid_grant")
+
+	tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:  deviceCodeGrantType,
+		DeviceCode: deviceResponse.GetDeviceCode(),
+		ClientId:   appUUID,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+}
+
+func TestVerifyDevice_WithoutToken(t *testing.T) {
+	ctx, st := New(t)
+
+	_, err := st.AuthClient.VerifyDevice(ctx, &ssov1.VerifyDeviceRequest{
+		UserCode: "BCDF-GHJK",
+		Approve:  true,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,161 @@
+package suite
+
+import (
+	"testing"
+	"time"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStartEmailLogin(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, _ := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+		Email:   email,
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	// Unknown emails are not revealed.
+	_, err = st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+		Email:   gofakeit.Email(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+		Email:   email,
+		AppUuid: gofakeit.UUID(),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid app_uuid")
+}
+
+func TestStartEmailLogin_Cooldown(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, _ := registerUser(ctx, st, appUUID)
+
+	// Known and unknown emails alike can not be sent a new login right away.
+	for _, email := range []string{email, gofakeit.Email()} {
+		_, err := st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+			Email:   email,
+			AppUuid: appUUID,
+		})
+		require.NoError(t, err)
+
+		_, err = st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+			Email:   email,
+			AppUuid: appUUID,
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.LessOrEqual(t, requireRetryDelay(t, err), time.Minute)
+	}
+}
+
+func TestCompleteEmailLogin_FailuresAreLimited(t *testing.T) {
+	ctx, st := New(t)
+
+	// maxEmailLoginFailures of the auth service.
+	const maxFailures = 10
+
+	appUUID := registerApp(ctx, st)
+	email, _ := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+		Email:   email,
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	for range maxFailures {
+		_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+			Email:   email,
+			AppUuid: appUUID,
+			Code:    "000000",
+		})
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid or expired code")
+	}
+
+	_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+		Email:   email,
+		AppUuid: appUUID,
+		Code:    "000000",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestCompleteEmailLogin_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.StartEmailLogin(ctx, &ssov1.StartEmailLoginRequest{
+		Email:   email,
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	// The code sent is not known here. Wrong codes are refused, and after a
+	// few of them the login stops accepting codes at all.
+	for range 6 {
+		_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+			Email:   email,
+			AppUuid: appUUID,
+			Code:    "000000",
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid or expired code")
+	}
+
+	_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+		Email:   gofakeit.Email(),
+		AppUuid: appUUID,
+		Code:    "123456",
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired code")
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	// Access tokens are signed like magic link tokens but are not accepted
+	// as such.
+	for _, token := range []string{loginResponse.GetToken(), gofakeit.LetterN(43)} {
+		_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{Token: token})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid or expired token")
+	}
+
+	_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+		Code:  "123456",
+		Token: loginResponse.GetToken(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = st.AuthClient.CompleteEmailLogin(ctx, &ssov1.CompleteEmailLoginRequest{
+		Email:   email,
+		AppUuid: appUUID,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "code or token is required")
+}
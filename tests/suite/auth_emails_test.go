@@ -0,0 +1,161 @@
+package suite
+
+import (
+	"testing"
+
+	"sso/internal/lib/pkce"
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRegister_InvalidEmail(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Username(),
+		Password: randomFakePassword(),
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid email")
+}
+
+func TestLogin_RequiresVerifiedEmail(t *testing.T) {
+	ctx, st := New(t)
+
+	adminCtx := adminContext(ctx, st)
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminCtx, &ssov1.RegisterAppRequest{
+		Name:                     gofakeit.Name(),
+		Secret:                   randomFakePassword(),
+		SigningAlgorithm:         userAppAlgorithm,
+		RequireEmailVerification: true,
+	})
+	require.NoError(t, err)
+
+	appUUID := registerAppResponse.GetAppUuid()
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginRequest := &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	}
+
+	_, err = st.AuthClient.Login(ctx, loginRequest)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = st.AuthClient.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{Token: gofakeit.LetterN(43)})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired token")
+
+	_, err = st.AuthClient.RequestEmailVerification(ctx, &ssov1.RequestEmailVerificationRequest{
+		Email:   email,
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	updateAppResponse, err := st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:                  appUUID,
+		RequireEmailVerification: proto.Bool(false),
+	})
+	require.NoError(t, err)
+	assert.False(t, updateAppResponse.GetApp().GetRequireEmailVerification())
+
+	loginResponse, err := st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	userInfoResponse, err := st.AuthClient.UserInfo(userCtx, &ssov1.UserInfoRequest{})
+	require.NoError(t, err)
+	assert.False(t, userInfoResponse.GetEmailVerified())
+}
+
+func TestRefresh_RequiresVerifiedEmail(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.UpdateApp(adminContext(ctx, st), &ssov1.UpdateAppRequest{
+		AppUuid:                  appUUID,
+		RequireEmailVerification: proto.Bool(true),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Refresh(ctx, &ssov1.RefreshRequest{
+		RefreshToken: loginResponse.GetRefreshToken(),
+		AppUuid:      appUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAuthorizationCode_RequiresVerifiedEmail(t *testing.T) {
+	ctx, st := New(t)
+
+	adminCtx := adminContext(ctx, st)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	verifier := gofakeit.LetterN(64)
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		Scope:               "openid email",
+		CodeChallenge:       pkce.Challenge(verifier),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:                  appUUID,
+		RequireEmailVerification: proto.Bool(true),
+	})
+	require.NoError(t, err)
+
+	tokenRequest := &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		CodeVerifier: verifier,
+	}
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "access_denied")
+
+	// The refused exchange does not consume the code.
+	_, err = st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:                  appUUID,
+		RequireEmailVerification: proto.Bool(false),
+	})
+	require.NoError(t, err)
+
+	tokenResponse, err := st.AuthClient.Token(ctx, tokenRequest)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+}
@@ -0,0 +1,55 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrospect(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	otherAppUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.True(t, introspectResponse.GetActive())
+	assert.Equal(t, email, introspectResponse.GetEmail())
+	assert.Equal(t, appUUID, introspectResponse.GetAppId())
+	assert.NotEmpty(t, introspectResponse.GetUid())
+
+	introspectResponse, err = st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: otherAppUUID,
+	})
+	require.NoError(t, err)
+	assert.False(t, introspectResponse.GetActive())
+
+	_, err = st.AuthClient.Logout(ctx, &ssov1.LogoutRequest{
+		Token: loginResponse.GetToken(),
+	})
+	require.NoError(t, err)
+
+	introspectResponse, err = st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.False(t, introspectResponse.GetActive())
+	assert.Empty(t, introspectResponse.GetEmail())
+}
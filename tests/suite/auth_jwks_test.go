@@ -0,0 +1,165 @@
+package suite
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"sso/internal/lib/jwk"
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin_RS256VerifiedWithJWKS(t *testing.T) {
+	ctx, st := New(t)
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           randomFakePassword(),
+		SigningAlgorithm: "RS256",
+	})
+	require.NoError(t, err)
+
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  registerAppResponse.GetAppUuid(),
+	})
+	require.NoError(t, err)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  registerAppResponse.GetAppUuid(),
+	})
+	require.NoError(t, err)
+
+	jwksResponse, err := st.AuthClient.GetJWKS(ctx, &ssov1.GetJWKSRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", jwksResponse.GetContentType())
+
+	var set jwk.Set
+	require.NoError(t, json.Unmarshal(jwksResponse.GetData(), &set))
+
+	tokenParsed, err := jwt.Parse(loginResponse.GetToken(), func(token *jwt.Token) (interface{}, error) {
+		for _, key := range set.Keys {
+			if key.Kid == token.Header["kid"] && key.Kty == "RSA" {
+				return rsaPublicKey(t, key), nil
+			}
+		}
+
+		return nil, assert.AnError
+	})
+	require.NoError(t, err)
+
+	claims, ok := tokenParsed.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, email, claims["email"].(string))
+	assert.Equal(t, "RS256", tokenParsed.Header["alg"])
+}
+
+func TestRegisterApp_UnsupportedAlgorithm(t *testing.T) {
+	ctx, st := New(t)
+
+	_, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           randomFakePassword(),
+		SigningAlgorithm: "none",
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "unsupported signing_algorithm")
+}
+
+// jwks returns the JWK Set the server publishes.
+func jwks(ctx context.Context, st *Suite) jwk.Set {
+	st.Helper()
+
+	jwksResponse, err := st.AuthClient.GetJWKS(ctx, &ssov1.GetJWKSRequest{})
+	require.NoError(st, err)
+
+	var set jwk.Set
+	require.NoError(st, json.Unmarshal(jwksResponse.GetData(), &set))
+
+	return set
+}
+
+func ecdsaPublicKey(t *testing.T, key jwk.JWK) *ecdsa.PublicKey {
+	t.Helper()
+
+	require.Equal(t, "P-256", key.Crv)
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	require.NoError(t, err)
+
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	require.NoError(t, err)
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+}
+
+func rsaPublicKey(t *testing.T, key jwk.JWK) *rsa.PublicKey {
+	t.Helper()
+
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	require.NoError(t, err)
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	require.NoError(t, err)
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+}
+
+func TestRotateSigningKeys_RetiredKeyStillPublished(t *testing.T) {
+	ctx, st := New(t)
+
+	before := jwksKids(ctx, st)
+
+	rotateResponse, err := st.AuthClient.RotateSigningKeys(adminContext(ctx, st), &ssov1.RotateSigningKeysRequest{
+		Algorithm: "ES256",
+	})
+	require.NoError(t, err)
+	require.Len(t, rotateResponse.GetKids(), 1)
+
+	after := jwksKids(ctx, st)
+
+	assert.Contains(t, after, rotateResponse.GetKids()[0])
+	for _, kid := range before {
+		assert.Contains(t, after, kid)
+	}
+}
+
+func jwksKids(ctx context.Context, st *Suite) []string {
+	st.Helper()
+
+	jwksResponse, err := st.AuthClient.GetJWKS(ctx, &ssov1.GetJWKSRequest{})
+	require.NoError(st, err)
+
+	var set jwk.Set
+	require.NoError(st, json.Unmarshal(jwksResponse.GetData(), &set))
+
+	kids := make([]string, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		kids = append(kids, key.Kid)
+	}
+
+	return kids
+}
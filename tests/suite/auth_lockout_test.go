@@ -0,0 +1,193 @@
+package suite
+
+import (
+	"testing"
+	"time"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogin_Backoff(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	for range st.Cfg.Lockout.BackoffAfter {
+		_, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+			Email:    email,
+			Password: randomFakePassword(),
+			AppUuid:  appUUID,
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	// Even the right password is refused until the delay has passed.
+	_, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	retryDelay := requireRetryDelay(t, err)
+	assert.LessOrEqual(t, retryDelay, st.Cfg.Lockout.BackoffBase)
+
+	time.Sleep(retryDelay)
+
+	loginContext(ctx, t, st, email, pass, appUUID)
+}
+
+func TestLogin_LockoutAndUnlock(t *testing.T) {
+	ctx, st := New(t)
+
+	if st.Cfg.Lockout.Threshold == 0 {
+		t.Skip("account lockout is disabled")
+	}
+
+	appUUID := registerApp(ctx, st)
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	registerResponse, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	for failures := 0; failures < st.Cfg.Lockout.Threshold; {
+		_, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+			Email:    email,
+			Password: randomFakePassword(),
+			AppUuid:  appUUID,
+		})
+		require.Error(t, err)
+
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			failures++
+		case codes.ResourceExhausted:
+			time.Sleep(requireRetryDelay(t, err))
+		default:
+			t.Fatalf("unexpected login error: %v", err)
+		}
+	}
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.ErrorContains(t, err, "account is locked")
+	assert.Greater(t, requireRetryDelay(t, err), time.Duration(0))
+
+	_, err = st.AuthClient.UnlockUser(ctx, &ssov1.UnlockUserRequest{UserUuid: registerResponse.GetUserUuid()})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = st.AuthClient.UnlockUser(adminContext(ctx, st), &ssov1.UnlockUserRequest{UserUuid: gofakeit.UUID()})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = st.AuthClient.UnlockUser(adminContext(ctx, st), &ssov1.UnlockUserRequest{UserUuid: registerResponse.GetUserUuid()})
+	require.NoError(t, err)
+
+	loginContext(ctx, t, st, email, pass, appUUID)
+}
+
+func TestRegister_ExistingEmailIsThrottled(t *testing.T) {
+	ctx, st := New(t)
+
+	email, pass := registerUser(ctx, st, registerApp(ctx, st))
+	otherAppUUID := registerApp(ctx, st)
+
+	// Joining another app checks the password like a login does.
+	for range st.Cfg.Lockout.BackoffAfter {
+		_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+			Email:    email,
+			Password: randomFakePassword(),
+			AppUuid:  otherAppUUID,
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+	}
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  otherAppUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  otherAppUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestVerifyMFA_FailuresCountAsLoginFailures(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginRequest := &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	}
+
+	secret, _ := enableTOTP(ctx, t, st, loginRequest)
+
+	loginResponse, err := st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+	require.NotEmpty(t, loginResponse.GetMfaChallengeId())
+
+	for range st.Cfg.Lockout.BackoffAfter {
+		_, err := st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+			MfaChallengeId: loginResponse.GetMfaChallengeId(),
+			Code:           totpCode(t, secret, 100),
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           totpCode(t, secret, 1),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// requireRetryDelay returns the delay of the RetryInfo detail of a login
+// refused by the lockout policy.
+func requireRetryDelay(t *testing.T, err error) time.Duration {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+
+	t.Fatalf("no retry info in %v", err)
+
+	return 0
+}
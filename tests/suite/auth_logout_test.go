@@ -0,0 +1,52 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogout_RevokesTokens(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email := gofakeit.Email()
+	pass := randomFakePassword()
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Logout(ctx, &ssov1.LogoutRequest{
+		Token:        loginResponse.GetToken(),
+		RefreshToken: loginResponse.GetRefreshToken(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Logout(ctx, &ssov1.LogoutRequest{
+		Token: loginResponse.GetToken(),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid token")
+
+	_, err = st.AuthClient.Refresh(ctx, &ssov1.RefreshRequest{
+		RefreshToken: loginResponse.GetRefreshToken(),
+		AppUuid:      appUUID,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid refresh token")
+}
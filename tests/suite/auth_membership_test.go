@@ -0,0 +1,93 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMembership_RegisterInSecondApp(t *testing.T) {
+	ctx, st := New(t)
+
+	firstAppUUID := registerApp(ctx, st)
+	secondAppUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, firstAppUUID)
+
+	_, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  secondAppUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: randomFakePassword(),
+		AppUuid:  secondAppUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	firstLoginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  firstAppUUID,
+	})
+	require.NoError(t, err)
+
+	registerResponse, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  secondAppUUID,
+	})
+	require.NoError(t, err)
+
+	secondLoginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  secondAppUUID,
+	})
+	require.NoError(t, err)
+
+	firstIntrospectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   firstLoginResponse.GetToken(),
+		AppUuid: firstAppUUID,
+	})
+	require.NoError(t, err)
+
+	secondIntrospectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   secondLoginResponse.GetToken(),
+		AppUuid: secondAppUUID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, registerResponse.GetUserUuid(), firstIntrospectResponse.GetUid())
+	assert.Equal(t, registerResponse.GetUserUuid(), secondIntrospectResponse.GetUid())
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  secondAppUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestRegister_UnknownApp(t *testing.T) {
+	ctx, st := New(t)
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Email(),
+		Password: randomFakePassword(),
+		AppUuid:  "00000000-0000-0000-0000-000000000000",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,314 @@
+package suite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"sso/internal/lib/pkce"
+	"sso/internal/lib/totp"
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTOTP_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginRequest := &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	}
+
+	secret, confirmCode := enableTOTP(ctx, t, st, loginRequest)
+
+	loginResponse, err := st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+	assert.Empty(t, loginResponse.GetToken())
+	require.NotEmpty(t, loginResponse.GetMfaChallengeId())
+
+	// The code that confirmed the enrollment can not be used again.
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           confirmCode,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid code")
+
+	verifyResponse, err := st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           totpCode(t, secret, 1),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, verifyResponse.GetToken())
+	assert.NotEmpty(t, verifyResponse.GetRefreshToken())
+
+	// A challenge completes a single login.
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           totpCode(t, secret, 1),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired mfa_challenge_id")
+}
+
+func TestTOTP_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.EnrollTOTP(ctx, &ssov1.EnrollTOTPRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	_, err = st.AuthClient.ConfirmTOTP(userCtx, &ssov1.ConfirmTOTPRequest{Code: "123456"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "totp enrollment is not started")
+
+	_, err = st.AuthClient.EnrollTOTP(userCtx, &ssov1.EnrollTOTPRequest{})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.ConfirmTOTP(userCtx, &ssov1.ConfirmTOTPRequest{Code: "abcdef"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid code")
+
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: gofakeit.LetterN(43),
+		Code:           "123456",
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired mfa_challenge_id")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginRequest := &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	}
+
+	loginResponse, err := st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	_, err = st.AuthClient.RegenerateRecoveryCodes(userCtx, &ssov1.RegenerateRecoveryCodesRequest{Code: "123456"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "mfa is not enabled")
+
+	enrollResponse, err := st.AuthClient.EnrollTOTP(userCtx, &ssov1.EnrollTOTPRequest{})
+	require.NoError(t, err)
+	require.Len(t, enrollResponse.GetRecoveryCodes(), 10)
+
+	_, err = st.AuthClient.ConfirmTOTP(userCtx, &ssov1.ConfirmTOTPRequest{
+		Code: totpCode(t, enrollResponse.GetSecret(), 0),
+	})
+	require.NoError(t, err)
+
+	recoveryCode := enrollResponse.GetRecoveryCodes()[0]
+
+	loginResponse, err = st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+
+	verifyResponse, err := st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           recoveryCode,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, verifyResponse.GetToken())
+	assert.EqualValues(t, 9, verifyResponse.GetRecoveryCodesRemaining())
+
+	// Recovery codes are single-use.
+	loginResponse, err = st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           recoveryCode,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid code")
+
+	regenerateResponse, err := st.AuthClient.RegenerateRecoveryCodes(userCtx, &ssov1.RegenerateRecoveryCodesRequest{
+		Code: enrollResponse.GetRecoveryCodes()[1],
+	})
+	require.NoError(t, err)
+	require.Len(t, regenerateResponse.GetRecoveryCodes(), 10)
+
+	// The previous codes no longer work.
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           enrollResponse.GetRecoveryCodes()[2],
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid code")
+
+	verifyResponse, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           strings.ToLower(regenerateResponse.GetRecoveryCodes()[0]),
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, verifyResponse.GetRecoveryCodesRemaining())
+}
+
+func TestTOTP_AuthorizeRequiresCode(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, _ := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	secret, _ := enableTOTP(ctx, t, st, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+
+	request := &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		Scope:               "openid",
+		CodeChallenge:       pkce.Challenge(gofakeit.LetterN(64)),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	}
+
+	_, err := st.AuthClient.Authorize(ctx, request)
+	requireOAuthError(t, err, "access_denied")
+
+	request.MfaCode = totpCode(t, secret, 1)
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, request)
+	require.NoError(t, err)
+	assert.NotEmpty(t, authorizeResponse.GetCode())
+}
+
+func TestTOTP_FailuresCountAcrossChallenges(t *testing.T) {
+	ctx, st := New(t)
+
+	// maxMFAFailures of the auth service.
+	const maxFailures = 10
+
+	appUUID, _ := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	secret, _ := enableTOTP(ctx, t, st, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+
+	request := &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		Scope:               "openid",
+		CodeChallenge:       pkce.Challenge(gofakeit.LetterN(64)),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+		MfaCode:             totpCode(t, secret, 100),
+	}
+
+	// The right password does not reset the count of wrong codes.
+	for range maxFailures {
+		_, err := st.AuthClient.Authorize(ctx, request)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid mfa_code")
+	}
+
+	request.MfaCode = totpCode(t, secret, 1)
+
+	_, err := st.AuthClient.Authorize(ctx, request)
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Nor does a new challenge.
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.VerifyMFA(ctx, &ssov1.VerifyMFARequest{
+		MfaChallengeId: loginResponse.GetMfaChallengeId(),
+		Code:           totpCode(t, secret, 1),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// enableTOTP enrolls and confirms TOTP for the user and returns the secret
+// and the code that confirmed it.
+func enableTOTP(
+	ctx context.Context,
+	t *testing.T,
+	st *Suite,
+	loginRequest *ssov1.LoginRequest,
+) (secret string, code string) {
+	t.Helper()
+
+	loginResponse, err := st.AuthClient.Login(ctx, loginRequest)
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	enrollResponse, err := st.AuthClient.EnrollTOTP(userCtx, &ssov1.EnrollTOTPRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, enrollResponse.GetSecret())
+	assert.Contains(t, enrollResponse.GetOtpauthUri(), "otpauth://totp/")
+
+	code = totpCode(t, enrollResponse.GetSecret(), 0)
+
+	_, err = st.AuthClient.ConfirmTOTP(userCtx, &ssov1.ConfirmTOTPRequest{Code: code})
+	require.NoError(t, err)
+
+	userInfoResponse, err := st.AuthClient.UserInfo(userCtx, &ssov1.UserInfoRequest{})
+	require.NoError(t, err)
+
+	getUserResponse, err := st.AuthClient.GetUser(adminContext(ctx, st), &ssov1.GetUserRequest{
+		UserUuid: userInfoResponse.GetSub(),
+	})
+	require.NoError(t, err)
+	assert.True(t, getUserResponse.GetUser().GetMfaEnabled())
+
+	return enrollResponse.GetSecret(), code
+}
+
+// totpCode returns the code of the time step offset steps from the current
+// one. Codes are accepted once, so each use in a test takes a later step.
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+	require.NoError(t, err)
+
+	return code
+}
@@ -0,0 +1,404 @@
+package suite
+
+import (
+	"context"
+	"testing"
+
+	"sso/internal/lib/pkce"
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const redirectURI = "https://client.example.com/callback"
+
+func TestAuthorizationCode_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	verifier := gofakeit.LetterN(64)
+	state := gofakeit.UUID()
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		Scope:               "openid email",
+		State:               state,
+		CodeChallenge:       pkce.Challenge(verifier),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, authorizeResponse.GetCode())
+	assert.Equal(t, state, authorizeResponse.GetState())
+
+	tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+	assert.NotEmpty(t, tokenResponse.GetRefreshToken())
+	assert.NotEmpty(t, tokenResponse.GetIdToken())
+	assert.Equal(t, "Bearer", tokenResponse.GetTokenType())
+	assert.Equal(t, "openid email", tokenResponse.GetScope())
+	assert.Positive(t, tokenResponse.GetExpiresIn())
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		CodeVerifier: verifier,
+	})
+	requireOAuthError(t, err, "invalid_grant")
+
+	// Reuse of the code revokes the tokens of its first redemption.
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "refresh_token",
+		RefreshToken: tokenResponse.GetRefreshToken(),
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+	})
+	requireOAuthError(t, err, "invalid_grant")
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   tokenResponse.GetAccessToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.False(t, introspectResponse.GetActive())
+}
+
+func TestAuthorizationCode_UsedCodeOfAnotherClient(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	otherAppUUID, otherAppSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	verifier := gofakeit.LetterN(64)
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		CodeChallenge:       pkce.Challenge(verifier),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	})
+	require.NoError(t, err)
+
+	tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+
+	// Another client presenting the used code is refused, but that does not
+	// revoke the tokens of the client the code was issued to.
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     otherAppUUID,
+		ClientSecret: otherAppSecret,
+		CodeVerifier: verifier,
+	})
+	requireOAuthError(t, err, "invalid_grant")
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   tokenResponse.GetAccessToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.True(t, introspectResponse.GetActive())
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "refresh_token",
+		RefreshToken: tokenResponse.GetRefreshToken(),
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+	})
+	require.NoError(t, err)
+}
+
+func TestAuthorizationCode_WrongVerifier(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	verifier := gofakeit.LetterN(64)
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		CodeChallenge:       pkce.Challenge(verifier),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	})
+	require.NoError(t, err)
+
+	tokenRequest := &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		ClientSecret: appSecret,
+		CodeVerifier: gofakeit.LetterN(64),
+	}
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "invalid_grant")
+
+	// A failed exchange does not burn the code.
+	tokenRequest.CodeVerifier = verifier
+
+	tokenResponse, err := st.AuthClient.Token(ctx, tokenRequest)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+}
+
+func TestAuthorizationCode_ClientAuthentication(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	verifier := gofakeit.LetterN(64)
+
+	authorizeRequest := &ssov1.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientId:            appUUID,
+		RedirectUri:         redirectURI,
+		CodeChallenge:       pkce.Challenge(verifier),
+		CodeChallengeMethod: pkce.MethodS256,
+		Email:               email,
+		Password:            pass,
+	}
+
+	authorizeResponse, err := st.AuthClient.Authorize(ctx, authorizeRequest)
+	require.NoError(t, err)
+
+	tokenRequest := &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     appUUID,
+		CodeVerifier: verifier,
+	}
+
+	// A confidential client must present its secret.
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	requireOAuthError(t, err, "invalid_client")
+
+	tokenRequest.ClientSecret = appSecret
+
+	_, err = st.AuthClient.Token(ctx, tokenRequest)
+	require.NoError(t, err)
+
+	// A public client is not authenticated, PKCE protects its codes.
+	publicAppUUID := registerPublicApp(ctx, st)
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  publicAppUUID,
+	})
+	require.NoError(t, err)
+
+	authorizeRequest.ClientId = publicAppUUID
+
+	authorizeResponse, err = st.AuthClient.Authorize(ctx, authorizeRequest)
+	require.NoError(t, err)
+
+	tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         authorizeResponse.GetCode(),
+		RedirectUri:  redirectURI,
+		ClientId:     publicAppUUID,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokenResponse.GetAccessToken())
+
+	_, err = st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+		GrantType: "client_credentials",
+		ClientId:  publicAppUUID,
+	})
+	requireOAuthError(t, err, "invalid_client")
+}
+
+func TestAuthorize_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, _ := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	challenge := pkce.Challenge(gofakeit.LetterN(64))
+
+	tests := []struct {
+		name     string
+		request  *ssov1.AuthorizeRequest
+		expected string
+	}{
+		{
+			name: "Unregistered redirect uri",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType:        "code",
+				ClientId:            appUUID,
+				RedirectUri:         "https://evil.example.com/callback",
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: pkce.MethodS256,
+				Email:               email,
+				Password:            pass,
+			},
+			expected: "invalid_request",
+		},
+		{
+			name: "Without code challenge",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType: "code",
+				ClientId:     appUUID,
+				RedirectUri:  redirectURI,
+				Email:        email,
+				Password:     pass,
+			},
+			expected: "invalid_request",
+		},
+		{
+			name: "Plain code challenge method",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType:        "code",
+				ClientId:            appUUID,
+				RedirectUri:         redirectURI,
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: "plain",
+				Email:               email,
+				Password:            pass,
+			},
+			expected: "invalid_request",
+		},
+		{
+			name: "Unsupported response type",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType:        "token",
+				ClientId:            appUUID,
+				RedirectUri:         redirectURI,
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: pkce.MethodS256,
+				Email:               email,
+				Password:            pass,
+			},
+			expected: "unsupported_response_type",
+		},
+		{
+			name: "Unknown scope",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType:        "code",
+				ClientId:            appUUID,
+				RedirectUri:         redirectURI,
+				Scope:               "openid admin",
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: pkce.MethodS256,
+				Email:               email,
+				Password:            pass,
+			},
+			expected: "invalid_scope",
+		},
+		{
+			name: "Wrong password",
+			request: &ssov1.AuthorizeRequest{
+				ResponseType:        "code",
+				ClientId:            appUUID,
+				RedirectUri:         redirectURI,
+				CodeChallenge:       challenge,
+				CodeChallengeMethod: pkce.MethodS256,
+				Email:               email,
+				Password:            randomFakePassword(),
+			},
+			expected: "access_denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.AuthClient.Authorize(ctx, tt.request)
+			requireOAuthError(t, err, tt.expected)
+		})
+	}
+}
+
+func registerOAuthApp(ctx context.Context, st *Suite) (appUUID string, appSecret string) {
+	st.Helper()
+
+	appSecret = randomFakePassword()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           appSecret,
+		SigningAlgorithm: userAppAlgorithm,
+		RedirectUris:     []string{redirectURI},
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, registerAppResponse.GetAppUuid())
+
+	return registerAppResponse.GetAppUuid(), appSecret
+}
+
+// registerPublicApp registers a public client, which is not authenticated
+// with a secret at the token endpoint.
+func registerPublicApp(ctx context.Context, st *Suite) string {
+	st.Helper()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           randomFakePassword(),
+		SigningAlgorithm: userAppAlgorithm,
+		RedirectUris:     []string{redirectURI},
+		PublicClient:     true,
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, registerAppResponse.GetAppUuid())
+
+	return registerAppResponse.GetAppUuid()
+}
+
+func requireOAuthError(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.NotEqual(t, codes.OK, st.Code())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, reason, info.GetReason())
+
+			return
+		}
+	}
+
+	t.Fatalf("error %q has no oauth error details", err)
+}
@@ -0,0 +1,85 @@
+package suite
+
+import (
+	"encoding/json"
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLogin_IDTokenAndUserInfo(t *testing.T) {
+	ctx, st := New(t)
+
+	// The app is registered with the default settings.
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:   gofakeit.Name(),
+		Secret: randomFakePassword(),
+	})
+	require.NoError(t, err)
+
+	appUUID := registerAppResponse.GetAppUuid()
+	email, pass := registerUser(ctx, st, appUUID)
+	nonce := gofakeit.UUID()
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+		Nonce:    nonce,
+	})
+	require.NoError(t, err)
+
+	set := jwks(ctx, st)
+
+	idToken, err := jwt.Parse(loginResponse.GetIdToken(), func(token *jwt.Token) (interface{}, error) {
+		for _, key := range set.Keys {
+			if key.Kid == token.Header["kid"] && key.Kty == "EC" {
+				return ecdsaPublicKey(t, key), nil
+			}
+		}
+
+		return nil, assert.AnError
+	})
+	require.NoError(t, err)
+
+	claims, ok := idToken.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, st.Cfg.Issuer, claims["iss"])
+	assert.Equal(t, appUUID, claims["aud"])
+	assert.Equal(t, nonce, claims["nonce"])
+	assert.NotEmpty(t, claims["iat"])
+
+	userInfoCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	userInfoResponse, err := st.AuthClient.UserInfo(userInfoCtx, &ssov1.UserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, claims["sub"], userInfoResponse.GetSub())
+	assert.Equal(t, email, userInfoResponse.GetEmail())
+}
+
+func TestUserInfo_WithoutToken(t *testing.T) {
+	ctx, st := New(t)
+
+	_, err := st.AuthClient.UserInfo(ctx, &ssov1.UserInfoRequest{})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "authorization is required")
+}
+
+func TestGetOpenIDConfiguration(t *testing.T) {
+	ctx, st := New(t)
+
+	response, err := st.AuthClient.GetOpenIDConfiguration(ctx, &ssov1.GetOpenIDConfigurationRequest{})
+	require.NoError(t, err)
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(response.GetData(), &document))
+	assert.Equal(t, st.Cfg.Issuer, document["issuer"])
+	assert.NotEmpty(t, document["jwks_uri"])
+	assert.NotEmpty(t, document["userinfo_endpoint"])
+}
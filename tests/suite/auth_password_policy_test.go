@@ -0,0 +1,187 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPasswordPolicy_Default(t *testing.T) {
+	ctx, st := New(t)
+
+	if st.Cfg.PasswordPolicy.MinLength < 4 {
+		t.Skip("the default password policy accepts short passwords")
+	}
+
+	appUUID := registerApp(ctx, st)
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Email(),
+		Password: "abc",
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, passwordViolations(t, err, "password"), "TOO_SHORT")
+
+	if st.Cfg.PasswordPolicy.DisallowEmail {
+		email := gofakeit.Email()
+
+		_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+			Email:    email,
+			Password: email,
+			AppUuid:  appUUID,
+		})
+		require.Error(t, err)
+		assert.Contains(t, passwordViolations(t, err, "password"), "CONTAINS_EMAIL")
+	}
+
+	email, pass := registerUser(ctx, st, appUUID)
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     "abc",
+	})
+	require.Error(t, err)
+	assert.Contains(t, passwordViolations(t, err, "new_password"), "TOO_SHORT")
+}
+
+func TestPasswordPolicy_AppOverride(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	policy := &ssov1.PasswordPolicy{
+		MinLength:      20,
+		MinCharClasses: 4,
+		DisallowEmail:  true,
+		MinStrength:    3,
+	}
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminCtx, &ssov1.RegisterAppRequest{
+		Name:           gofakeit.Name(),
+		Secret:         randomFakePassword(),
+		PasswordPolicy: policy,
+	})
+	require.NoError(t, err)
+
+	appUUID := registerAppResponse.GetAppUuid()
+
+	getResponse, err := st.AuthClient.GetApp(adminCtx, &ssov1.GetAppRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+	assert.Equal(t, policy.GetMinLength(), getResponse.GetApp().GetPasswordPolicy().GetMinLength())
+	assert.Equal(t, policy.GetMinStrength(), getResponse.GetApp().GetPasswordPolicy().GetMinStrength())
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Email(),
+		Password: randomFakePassword(),
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+	assert.Contains(t, passwordViolations(t, err, "password"), "TOO_SHORT")
+
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    gofakeit.Email(),
+		Password: "aA1!" + gofakeit.Password(true, true, true, true, false, 20),
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	// Without the override the app uses the default policy again.
+	updateResponse, err := st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:             appUUID,
+		ResetPasswordPolicy: true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, updateResponse.GetApp().GetPasswordPolicy())
+
+	registerUser(ctx, st, appUUID)
+
+	_, err = st.AuthClient.UpdateApp(adminCtx, &ssov1.UpdateAppRequest{
+		AppUuid:        appUUID,
+		PasswordPolicy: &ssov1.PasswordPolicy{MinCharClasses: 5},
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid password_policy")
+
+	_, err = st.AuthClient.RegisterApp(adminCtx, &ssov1.RegisterAppRequest{
+		Name:           gofakeit.Name(),
+		Secret:         randomFakePassword(),
+		PasswordPolicy: &ssov1.PasswordPolicy{MinLength: 12, MaxLength: 8},
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid password_policy")
+}
+
+func TestPasswordPolicy_StrictestOfUserApps(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:   gofakeit.Name(),
+		Secret: randomFakePassword(),
+		PasswordPolicy: &ssov1.PasswordPolicy{
+			MinLength:      30,
+			MinCharClasses: 4,
+		},
+	})
+	require.NoError(t, err)
+
+	// Joining with the existing password does not change it.
+	_, err = st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  registerAppResponse.GetAppUuid(),
+	})
+	require.NoError(t, err)
+
+	// The new password is shared with the strict app, so its policy applies
+	// even when the password is changed from the other app.
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     randomFakePassword(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, passwordViolations(t, err, "new_password"), "TOO_SHORT")
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     "aA1!" + gofakeit.Password(true, true, true, true, false, 30),
+	})
+	require.NoError(t, err)
+}
+
+// passwordViolations returns the reasons of the violations of the field in
+// the BadRequest detail of a password refused by the password policy.
+func passwordViolations(t *testing.T, err error, field string) []string {
+	t.Helper()
+
+	var reasons []string
+
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				assert.Equal(t, field, violation.GetField())
+				assert.NotEmpty(t, violation.GetDescription())
+
+				reasons = append(reasons, violation.GetReason())
+			}
+		}
+	}
+
+	require.NotEmpty(t, reasons, "no password violations in %v", err)
+
+	return reasons
+}
@@ -0,0 +1,127 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestChangePassword_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+	newPass := randomFakePassword()
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     newPass,
+	})
+	require.NoError(t, err)
+
+	// The sessions started with the old password end.
+	_, err = st.AuthClient.UserInfo(userCtx, &ssov1.UserInfoRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = st.AuthClient.Refresh(ctx, &ssov1.RefreshRequest{
+		RefreshToken: loginResponse.GetRefreshToken(),
+		AppUuid:      appUUID,
+	})
+	require.Error(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: newPass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+}
+
+func TestChangePassword_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	request := &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+		NewPassword:     randomFakePassword(),
+	}
+
+	_, err := st.AuthClient.ChangePassword(ctx, request)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: randomFakePassword(),
+		NewPassword:     randomFakePassword(),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid current_password")
+
+	_, err = st.AuthClient.ChangePassword(userCtx, &ssov1.ChangePasswordRequest{
+		CurrentPassword: pass,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "new_password is required")
+}
+
+func TestPasswordReset_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+
+	// Unknown emails are not revealed.
+	_, err := st.AuthClient.RequestPasswordReset(ctx, &ssov1.RequestPasswordResetRequest{
+		Email:   gofakeit.Email(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.RequestPasswordReset(ctx, &ssov1.RequestPasswordResetRequest{
+		Email:   gofakeit.Email(),
+		AppUuid: gofakeit.UUID(),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid app_uuid")
+
+	_, err = st.AuthClient.ConfirmPasswordReset(ctx, &ssov1.ConfirmPasswordResetRequest{
+		Token:       gofakeit.LetterN(43),
+		NewPassword: randomFakePassword(),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired token")
+}
@@ -0,0 +1,228 @@
+package suite
+
+import (
+	"context"
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRBAC_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	createRoleResponse, err := st.AuthClient.CreateRole(adminContext(ctx, st), &ssov1.CreateRoleRequest{
+		AppUuid:     appUUID,
+		Name:        "manager",
+		Permissions: []string{"orders:read"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, createRoleResponse.GetRoleId())
+
+	_, err = st.AuthClient.AddRolePermission(adminContext(ctx, st), &ssov1.AddRolePermissionRequest{
+		RoleId:     createRoleResponse.GetRoleId(),
+		Permission: "orders:write",
+	})
+	require.NoError(t, err)
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, introspectResponse.GetRoles())
+
+	userUUID := introspectResponse.GetUid()
+
+	checkResponse, err := st.AuthClient.CheckPermission(adminContext(ctx, st), &ssov1.CheckPermissionRequest{
+		UserUuid:   userUUID,
+		AppUuid:    appUUID,
+		Permission: "orders:write",
+	})
+	require.NoError(t, err)
+	assert.False(t, checkResponse.GetAllowed())
+
+	_, err = st.AuthClient.AssignRole(adminContext(ctx, st), &ssov1.AssignRoleRequest{
+		UserUuid: userUUID,
+		RoleId:   createRoleResponse.GetRoleId(),
+	})
+	require.NoError(t, err)
+
+	checkResponse, err = st.AuthClient.CheckPermission(adminContext(ctx, st), &ssov1.CheckPermissionRequest{
+		UserUuid:   userUUID,
+		AppUuid:    appUUID,
+		Permission: "orders:write",
+	})
+	require.NoError(t, err)
+	assert.True(t, checkResponse.GetAllowed())
+
+	checkResponse, err = st.AuthClient.CheckPermission(adminContext(ctx, st), &ssov1.CheckPermissionRequest{
+		UserUuid:   userUUID,
+		AppUuid:    appUUID,
+		Permission: "orders:delete",
+	})
+	require.NoError(t, err)
+	assert.False(t, checkResponse.GetAllowed())
+
+	loginResponse, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	introspectResponse, err = st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"manager"}, introspectResponse.GetRoles())
+	assert.Equal(t, []string{"orders:read", "orders:write"}, introspectResponse.GetPermissions())
+}
+
+func TestRBAC_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	otherAppUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, otherAppUUID)
+
+	createRoleResponse, err := st.AuthClient.CreateRole(adminContext(ctx, st), &ssov1.CreateRoleRequest{
+		AppUuid: appUUID,
+		Name:    "viewer",
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.CreateRole(adminContext(ctx, st), &ssov1.CreateRoleRequest{
+		AppUuid: appUUID,
+		Name:    "viewer",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = st.AuthClient.CreateRole(adminContext(ctx, st), &ssov1.CreateRoleRequest{
+		AppUuid:     appUUID,
+		Name:        "editor",
+		Permissions: []string{"orders write"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  otherAppUUID,
+	})
+	require.NoError(t, err)
+
+	introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+		Token:   loginResponse.GetToken(),
+		AppUuid: otherAppUUID,
+	})
+	require.NoError(t, err)
+
+	// Roles of an app can only be given to its members.
+	_, err = st.AuthClient.AssignRole(adminContext(ctx, st), &ssov1.AssignRoleRequest{
+		UserUuid: introspectResponse.GetUid(),
+		RoleId:   createRoleResponse.GetRoleId(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = st.AuthClient.AddRolePermission(adminContext(ctx, st), &ssov1.AddRolePermissionRequest{
+		RoleId:     "00000000-0000-0000-0000-000000000000",
+		Permission: "orders:read",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCheckPermission_Callers(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID, appSecret := registerOAuthApp(ctx, st)
+	otherAppUUID, otherAppSecret := registerOAuthApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	otherEmail, otherPass := registerUser(ctx, st, appUUID)
+
+	userContext := func(email string, pass string) (context.Context, string) {
+		loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+			Email:    email,
+			Password: pass,
+			AppUuid:  appUUID,
+		})
+		require.NoError(t, err)
+
+		introspectResponse, err := st.AuthClient.Introspect(ctx, &ssov1.IntrospectRequest{
+			Token:   loginResponse.GetToken(),
+			AppUuid: appUUID,
+		})
+		require.NoError(t, err)
+
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken()),
+			introspectResponse.GetUid()
+	}
+
+	clientContext := func(appUUID string, appSecret string) context.Context {
+		tokenResponse, err := st.AuthClient.Token(ctx, &ssov1.TokenRequest{
+			GrantType:    "client_credentials",
+			ClientId:     appUUID,
+			ClientSecret: appSecret,
+		})
+		require.NoError(t, err)
+
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tokenResponse.GetAccessToken())
+	}
+
+	userCtx, userUUID := userContext(email, pass)
+	_, otherUserUUID := userContext(otherEmail, otherPass)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		userUUID string
+		appUUID  string
+		expected codes.Code
+	}{
+		{name: "Admin", ctx: adminContext(ctx, st), userUUID: userUUID, appUUID: appUUID, expected: codes.OK},
+		{name: "App for its user", ctx: clientContext(appUUID, appSecret), userUUID: userUUID, appUUID: appUUID, expected: codes.OK},
+		{name: "User for themselves", ctx: userCtx, userUUID: userUUID, appUUID: appUUID, expected: codes.OK},
+		{
+			name:     "App for another app",
+			ctx:      clientContext(otherAppUUID, otherAppSecret),
+			userUUID: userUUID,
+			appUUID:  appUUID,
+			expected: codes.PermissionDenied,
+		},
+		{name: "User for another user", ctx: userCtx, userUUID: otherUserUUID, appUUID: appUUID, expected: codes.PermissionDenied},
+		{name: "User in another app", ctx: userCtx, userUUID: userUUID, appUUID: otherAppUUID, expected: codes.PermissionDenied},
+		{name: "Without token", ctx: ctx, userUUID: userUUID, appUUID: appUUID, expected: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkResponse, err := st.AuthClient.CheckPermission(tt.ctx, &ssov1.CheckPermissionRequest{
+				UserUuid:   tt.userUUID,
+				AppUuid:    tt.appUUID,
+				Permission: "orders:read",
+			})
+			require.Equal(t, tt.expected, status.Code(err))
+			assert.False(t, checkResponse.GetAllowed())
+		})
+	}
+}
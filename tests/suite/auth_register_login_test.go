@@ -1,6 +1,7 @@
 package suite
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -17,6 +18,10 @@ const (
 	appSecret  = "test-secret"
 
 	passDefeaultLen = 10
+
+	// userAppAlgorithm is the signing algorithm of the apps whose tokens are
+	// used with the user RPCs, which do not accept HS256 tokens.
+	userAppAlgorithm = "ES256"
 )
 
 func TestRegister_Login_HappyPath(t *testing.T) {
@@ -28,9 +33,10 @@ func TestRegister_Login_HappyPath(t *testing.T) {
 	appName := gofakeit.Name()
 	appSecret := randomFakePassword()
 
-	registerAppResponse, err := st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
-		Name:   appName,
-		Secret: appSecret,
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             appName,
+		Secret:           appSecret,
+		SigningAlgorithm: "HS256",
 	})
 
 	require.NoError(t, err)
@@ -86,7 +92,7 @@ func TestRegister_Login_DuplicatedRegistration(t *testing.T) {
 	appName := gofakeit.Name()
 	appSecret := randomFakePassword()
 
-	registerAppResponse, err := st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
 		Name:   appName,
 		Secret: appSecret,
 	})
@@ -121,7 +127,7 @@ func TestRegisterApp_DuplicatedRegistration(t *testing.T) {
 	appName := gofakeit.Name()
 	appSecret := randomFakePassword()
 
-	registerAppResponse, err := st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
 		Name:   appName,
 		Secret: appSecret,
 	})
@@ -129,7 +135,7 @@ func TestRegisterApp_DuplicatedRegistration(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, registerAppResponse.GetAppUuid())
 
-	registerAppResponse, err = st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
+	registerAppResponse, err = st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
 		Name:   appName,
 		Secret: appSecret,
 	})
@@ -146,7 +152,7 @@ func TestRegister_FailCase(t *testing.T) {
 	appName := gofakeit.Name()
 	appSecret := randomFakePassword()
 
-	registerAppResponse, err := st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
 		Name:   appName,
 		Secret: appSecret,
 	})
@@ -239,7 +245,7 @@ func TestRegisterApp_FailCase(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := st.AuthClient.RegisterApp(ctx, &ssov1.RegisterAppRequest{
+			_, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
 				Name: tt.appName,
 				Secret: tt.appSecret,
 			})
@@ -253,3 +259,36 @@ func TestRegisterApp_FailCase(t *testing.T) {
 func randomFakePassword() string {
 	return gofakeit.Password(true, true, true, true, true, passDefeaultLen)
 }
+
+func registerApp(ctx context.Context, st *Suite) string {
+	st.Helper()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           randomFakePassword(),
+		SigningAlgorithm: userAppAlgorithm,
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, registerAppResponse.GetAppUuid())
+
+	return registerAppResponse.GetAppUuid()
+}
+
+func registerUser(ctx context.Context, st *Suite, appUUID string) (email string, pass string) {
+	st.Helper()
+
+	email = gofakeit.Email()
+	pass = randomFakePassword()
+
+	registerResponse, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, registerResponse.GetUserUuid())
+
+	return email, pass
+}
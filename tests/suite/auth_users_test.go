@@ -0,0 +1,150 @@
+package suite
+
+import (
+	"testing"
+
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserManagement_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	listResponse, err := st.AuthClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		AppUuid: appUUID,
+	})
+	require.NoError(t, err)
+	require.Len(t, listResponse.GetUsers(), 1)
+	assert.Empty(t, listResponse.GetNextPageToken())
+
+	user := listResponse.GetUsers()[0]
+	assert.Equal(t, email, user.GetEmail())
+	assert.Equal(t, []string{appUUID}, user.GetAppUuids())
+	assert.Positive(t, user.GetCreatedAt())
+
+	getResponse, err := st.AuthClient.GetUser(adminCtx, &ssov1.GetUserRequest{
+		UserUuid: user.GetUserUuid(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, email, getResponse.GetUser().GetEmail())
+
+	newEmail := gofakeit.Email()
+
+	updateResponse, err := st.AuthClient.UpdateUser(adminCtx, &ssov1.UpdateUserRequest{
+		UserUuid: user.GetUserUuid(),
+		Email:    &newEmail,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, newEmail, updateResponse.GetUser().GetEmail())
+	assert.False(t, updateResponse.GetUser().GetIsAdmin())
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    newEmail,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.DeleteUser(adminCtx, &ssov1.DeleteUserRequest{
+		UserUuid: user.GetUserUuid(),
+	})
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    newEmail,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.Error(t, err)
+
+	_, err = st.AuthClient.GetUser(adminCtx, &ssov1.GetUserRequest{
+		UserUuid: user.GetUserUuid(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = st.AuthClient.DeleteUser(adminCtx, &ssov1.DeleteUserRequest{
+		UserUuid: user.GetUserUuid(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListUsers_Pagination(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID := registerApp(ctx, st)
+
+	registered := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		email, _ := registerUser(ctx, st, appUUID)
+		registered[email] = true
+	}
+
+	listed := map[string]bool{}
+	pageToken := ""
+
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+
+		listResponse, err := st.AuthClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+			PageSize:  2,
+			PageToken: pageToken,
+			AppUuid:   appUUID,
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(listResponse.GetUsers()), 2)
+
+		for _, user := range listResponse.GetUsers() {
+			assert.False(t, listed[user.GetEmail()], "user listed twice")
+			listed[user.GetEmail()] = true
+		}
+
+		pageToken = listResponse.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, registered, listed)
+
+	_, err := st.AuthClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		PageToken: "not a token",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListUsers_EmailPrefix(t *testing.T) {
+	ctx, st := New(t)
+	adminCtx := adminContext(ctx, st)
+
+	appUUID := registerApp(ctx, st)
+	email, _ := registerUser(ctx, st, appUUID)
+	registerUser(ctx, st, appUUID)
+
+	listResponse, err := st.AuthClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		AppUuid:     appUUID,
+		EmailPrefix: email,
+	})
+	require.NoError(t, err)
+	require.Len(t, listResponse.GetUsers(), 1)
+	assert.Equal(t, email, listResponse.GetUsers()[0].GetEmail())
+
+	listResponse, err = st.AuthClient.ListUsers(adminCtx, &ssov1.ListUsersRequest{
+		AppUuid:      appUUID,
+		CreatedAfter: listResponse.GetUsers()[0].GetCreatedAt() + 3600,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, listResponse.GetUsers())
+}
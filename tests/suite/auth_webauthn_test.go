@@ -0,0 +1,296 @@
+package suite
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"sso/internal/lib/webauthn"
+	"sso/internal/lib/webauthn/webauthntest"
+	ssov1 "sso/streaming/go/sso"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const webAuthnRPID = "example.com"
+
+func TestWebAuthn_HappyPath(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerWebAuthnApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+
+	authenticator := webauthntest.New("https://login." + webAuthnRPID)
+
+	credentialID := registerPasskey(userCtx, t, st, authenticator)
+
+	beginResponse, err := st.AuthClient.BeginWebAuthnLogin(ctx, &ssov1.BeginWebAuthnLoginRequest{
+		AppUuid: appUUID,
+		Nonce:   "webauthn-nonce",
+	})
+	require.NoError(t, err)
+
+	var options webauthn.RequestOptions
+	require.NoError(t, json.Unmarshal([]byte(beginResponse.GetOptionsJson()), &options))
+	assert.Equal(t, webAuthnRPID, options.RPID)
+	assert.Empty(t, options.AllowCredentials)
+
+	assertion, err := authenticator.Get(options)
+	require.NoError(t, err)
+	assert.Equal(t, credentialID, webauthn.EncodeID(assertion.CredentialID))
+
+	finishRequest := &ssov1.FinishWebAuthnLoginRequest{
+		SessionId:         beginResponse.GetSessionId(),
+		CredentialId:      assertion.CredentialID,
+		ClientDataJson:    assertion.ClientDataJSON,
+		AuthenticatorData: assertion.AuthenticatorData,
+		Signature:         assertion.Signature,
+		UserHandle:        assertion.UserHandle,
+	}
+
+	finishResponse, err := st.AuthClient.FinishWebAuthnLogin(ctx, finishRequest)
+	require.NoError(t, err)
+	assert.NotEmpty(t, finishResponse.GetToken())
+	assert.NotEmpty(t, finishResponse.GetRefreshToken())
+
+	userInfo, err := st.AuthClient.UserInfo(
+		metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+finishResponse.GetToken()),
+		&ssov1.UserInfoRequest{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, email, userInfo.GetEmail())
+
+	// A session completes a single login.
+	_, err = st.AuthClient.FinishWebAuthnLogin(ctx, finishRequest)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired session_id")
+
+	// Registering the same authenticator again is refused by the options.
+	beginRegistration, err := st.AuthClient.BeginWebAuthnRegistration(userCtx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.NoError(t, err)
+
+	var creationOptions webauthn.CreationOptions
+	require.NoError(t, json.Unmarshal([]byte(beginRegistration.GetOptionsJson()), &creationOptions))
+	require.Len(t, creationOptions.ExcludeCredentials, 1)
+	assert.Equal(t, credentialID, creationOptions.ExcludeCredentials[0].ID)
+}
+
+func TestWebAuthn_AssertionForAnotherSession(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerWebAuthnApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+
+	authenticator := webauthntest.New("https://" + webAuthnRPID)
+	registerPasskey(userCtx, t, st, authenticator)
+
+	first, err := st.AuthClient.BeginWebAuthnLogin(ctx, &ssov1.BeginWebAuthnLoginRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+
+	second, err := st.AuthClient.BeginWebAuthnLogin(ctx, &ssov1.BeginWebAuthnLoginRequest{AppUuid: appUUID})
+	require.NoError(t, err)
+
+	var options webauthn.RequestOptions
+	require.NoError(t, json.Unmarshal([]byte(first.GetOptionsJson()), &options))
+
+	assertion, err := authenticator.Get(options)
+	require.NoError(t, err)
+
+	// The assertion signs the challenge of the first session only.
+	_, err = st.AuthClient.FinishWebAuthnLogin(ctx, &ssov1.FinishWebAuthnLoginRequest{
+		SessionId:         second.GetSessionId(),
+		CredentialId:      assertion.CredentialID,
+		ClientDataJson:    assertion.ClientDataJSON,
+		AuthenticatorData: assertion.AuthenticatorData,
+		Signature:         assertion.Signature,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid credentials")
+}
+
+func TestWebAuthn_FailCases(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+
+	_, err := st.AuthClient.BeginWebAuthnRegistration(ctx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = st.AuthClient.BeginWebAuthnRegistration(userCtx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "webauthn is not configured for the app")
+
+	_, err = st.AuthClient.BeginWebAuthnLogin(ctx, &ssov1.BeginWebAuthnLoginRequest{AppUuid: appUUID})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "webauthn is not configured for the app")
+
+	_, err = st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:         gofakeit.Name(),
+		Secret:       randomFakePassword(),
+		WebauthnRpId: "https://example.com",
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid webauthn_rp_id")
+
+	// A public suffix would share the passkeys with every site under it.
+	for _, rpID := range []string{"com", "co.uk", "intranet"} {
+		_, err = st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+			Name:         gofakeit.Name(),
+			Secret:       randomFakePassword(),
+			WebauthnRpId: rpID,
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "invalid webauthn_rp_id", rpID)
+	}
+
+	_, err = st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:            gofakeit.Name(),
+		Secret:          randomFakePassword(),
+		WebauthnRpId:    webAuthnRPID,
+		WebauthnOrigins: []string{"https://example.org"},
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid webauthn_origins")
+
+	webAuthnAppUUID := registerWebAuthnApp(ctx, st)
+	email, pass = registerUser(ctx, st, webAuthnAppUUID)
+	userCtx = loginContext(ctx, t, st, email, pass, webAuthnAppUUID)
+
+	beginResponse, err := st.AuthClient.BeginWebAuthnRegistration(userCtx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.NoError(t, err)
+
+	var options webauthn.CreationOptions
+	require.NoError(t, json.Unmarshal([]byte(beginResponse.GetOptionsJson()), &options))
+
+	// An origin that is not allowed for the app is rejected, even under the
+	// RP ID.
+	attestation, err := webauthntest.New("https://evil." + webAuthnRPID).Create(options)
+	require.NoError(t, err)
+
+	_, err = st.AuthClient.FinishWebAuthnRegistration(userCtx, &ssov1.FinishWebAuthnRegistrationRequest{
+		SessionId:         beginResponse.GetSessionId(),
+		ClientDataJson:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid attestation")
+
+	_, err = st.AuthClient.FinishWebAuthnLogin(ctx, &ssov1.FinishWebAuthnLoginRequest{
+		SessionId:         gofakeit.LetterN(43),
+		CredentialId:      []byte("credential"),
+		ClientDataJson:    []byte("{}"),
+		AuthenticatorData: []byte("data"),
+		Signature:         []byte("signature"),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired session_id")
+}
+
+func TestWebAuthn_RegistrationOfAnotherApp(t *testing.T) {
+	ctx, st := New(t)
+
+	appUUID := registerWebAuthnApp(ctx, st)
+	otherAppUUID := registerWebAuthnApp(ctx, st)
+	email, pass := registerUser(ctx, st, appUUID)
+
+	_, err := st.AuthClient.Register(ctx, &ssov1.RegisterRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  otherAppUUID,
+	})
+	require.NoError(t, err)
+
+	userCtx := loginContext(ctx, t, st, email, pass, appUUID)
+	otherUserCtx := loginContext(ctx, t, st, email, pass, otherAppUUID)
+
+	beginResponse, err := st.AuthClient.BeginWebAuthnRegistration(userCtx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.NoError(t, err)
+
+	var options webauthn.CreationOptions
+	require.NoError(t, json.Unmarshal([]byte(beginResponse.GetOptionsJson()), &options))
+
+	attestation, err := webauthntest.New("https://" + webAuthnRPID).Create(options)
+	require.NoError(t, err)
+
+	// The session was started with a token of the first app.
+	_, err = st.AuthClient.FinishWebAuthnRegistration(otherUserCtx, &ssov1.FinishWebAuthnRegistrationRequest{
+		SessionId:         beginResponse.GetSessionId(),
+		ClientDataJson:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid or expired session_id")
+}
+
+func registerWebAuthnApp(ctx context.Context, st *Suite) string {
+	st.Helper()
+
+	registerAppResponse, err := st.AuthClient.RegisterApp(adminContext(ctx, st), &ssov1.RegisterAppRequest{
+		Name:             gofakeit.Name(),
+		Secret:           randomFakePassword(),
+		SigningAlgorithm: userAppAlgorithm,
+		WebauthnRpId:     webAuthnRPID,
+		WebauthnOrigins:  []string{"https://" + webAuthnRPID, "https://login." + webAuthnRPID},
+	})
+
+	require.NoError(st, err)
+	require.NotEmpty(st, registerAppResponse.GetAppUuid())
+
+	return registerAppResponse.GetAppUuid()
+}
+
+func loginContext(ctx context.Context, t *testing.T, st *Suite, email string, pass string, appUUID string) context.Context {
+	t.Helper()
+
+	loginResponse, err := st.AuthClient.Login(ctx, &ssov1.LoginRequest{
+		Email:    email,
+		Password: pass,
+		AppUuid:  appUUID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, loginResponse.GetToken())
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+loginResponse.GetToken())
+}
+
+// registerPasskey drives the registration ceremony with the authenticator and
+// returns the ID of the new credential.
+func registerPasskey(
+	userCtx context.Context,
+	t *testing.T,
+	st *Suite,
+	authenticator *webauthntest.Authenticator,
+) string {
+	t.Helper()
+
+	beginResponse, err := st.AuthClient.BeginWebAuthnRegistration(userCtx, &ssov1.BeginWebAuthnRegistrationRequest{})
+	require.NoError(t, err)
+
+	var options webauthn.CreationOptions
+	require.NoError(t, json.Unmarshal([]byte(beginResponse.GetOptionsJson()), &options))
+	assert.Equal(t, webAuthnRPID, options.RP.ID)
+
+	attestation, err := authenticator.Create(options)
+	require.NoError(t, err)
+
+	finishResponse, err := st.AuthClient.FinishWebAuthnRegistration(userCtx, &ssov1.FinishWebAuthnRegistrationRequest{
+		SessionId:         beginResponse.GetSessionId(),
+		Name:              "Laptop",
+		ClientDataJson:    attestation.ClientDataJSON,
+		AttestationObject: attestation.AttestationObject,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, webauthn.EncodeID(attestation.CredentialID), finishResponse.GetCredentialId())
+
+	return finishResponse.GetCredentialId()
+}